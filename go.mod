@@ -0,0 +1,5 @@
+module github.com/larrylv/go-weibo
+
+go 1.21
+
+require github.com/google/go-querystring v1.1.0
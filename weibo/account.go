@@ -0,0 +1,49 @@
+package weibo
+
+// AccountService handles communication with the account related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/%E5%BE%AE%E5%8D%9AAPI
+type AccountService struct {
+	client *Client
+}
+
+// RateLimitStatus represents the API rate limits of the authenticated user
+// and the IP address of the request.
+type RateLimitStatus struct {
+	APIRateLimits      []APIRateLimit `json:"api_rate_limits,omitempty"`
+	IPLimit            *int           `json:"ip_limit,omitempty"`
+	LimitTimeUnit      *string        `json:"limit_time_unit,omitempty"`
+	RemainingIPHits    *int           `json:"remaining_ip_hits,omitempty"`
+	UserLimit          *int           `json:"user_limit,omitempty"`
+	RemainingUserHits  *int           `json:"remaining_user_hits,omitempty"`
+	ResetTime          *string        `json:"reset_time,omitempty"`
+	ResetTimeInSeconds *int           `json:"reset_time_in_seconds,omitempty"`
+}
+
+// APIRateLimit represents the rate limit of a single API endpoint, such as
+// "/statuses/update".
+type APIRateLimit struct {
+	API           *string `json:"api,omitempty"`
+	Limit         *int    `json:"limit,omitempty"`
+	LimitTimeUnit *string `json:"limit_time_unit,omitempty"`
+	RemainingHits *int    `json:"remaining_hits,omitempty"`
+}
+
+// RateLimitStatus fetches the API rate limits of the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/account/rate_limit_status
+func (s *AccountService) RateLimitStatus() (*RateLimitStatus, *Response, error) {
+	req, err := s.client.NewRequest("GET", "account/rate_limit_status.json", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(RateLimitStatus)
+	resp, err := s.client.Do(req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, err
+}
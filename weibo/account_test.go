@@ -0,0 +1,46 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestAccountRateLimitStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+			"api_rate_limits":[{"api":"/statuses/update","limit":30,"limit_time_unit":"HOURS","remaining_hits":29}],
+			"ip_limit":10000,"limit_time_unit":"HOURS","remaining_ip_hits":10000,
+			"user_limit":150,"remaining_user_hits":140,
+			"reset_time":"2011-06-03 18:00:00","reset_time_in_seconds":2715}`)
+	})
+
+	status, _, err := client.Account.RateLimitStatus()
+	if err != nil {
+		t.Errorf("Account.RateLimitStatus returned error: %v", err)
+	}
+
+	want := &RateLimitStatus{
+		APIRateLimits: []APIRateLimit{{
+			API:           String("/statuses/update"),
+			Limit:         Int(30),
+			LimitTimeUnit: String("HOURS"),
+			RemainingHits: Int(29),
+		}},
+		IPLimit:            Int(10000),
+		LimitTimeUnit:      String("HOURS"),
+		RemainingIPHits:    Int(10000),
+		UserLimit:          Int(150),
+		RemainingUserHits:  Int(140),
+		ResetTime:          String("2011-06-03 18:00:00"),
+		ResetTimeInSeconds: Int(2715),
+	}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("Account.RateLimitStatus returned %+v, want %+v", status, want)
+	}
+}
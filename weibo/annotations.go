@@ -0,0 +1,87 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// maxAnnotationsLength is the maximum length in bytes of the JSON encoded
+// annotations of a status.
+const maxAnnotationsLength = 512
+
+// Annotation represents an arbitrary JSON object attached to a Weibo status
+// by an application.
+type Annotation map[string]interface{}
+
+// Annotations represents the annotations of a Weibo status.  They are JSON
+// encoded when sent as a form field, and decoded from the "annotations"
+// array of a status.
+type Annotations []Annotation
+
+// AnnotationsLengthError reports annotations whose JSON encoding exceeds
+// the limit of the Weibo API.
+type AnnotationsLengthError struct {
+	Length int // length in bytes of the JSON encoded annotations
+}
+
+func (e *AnnotationsLengthError) Error() string {
+	return fmt.Sprintf("annotations are %d bytes, exceeding the limit of %d bytes", e.Length, maxAnnotationsLength)
+}
+
+// Lookup returns the value of key in the first annotation containing it.
+func (a Annotations) Lookup(key string) (interface{}, bool) {
+	for _, annotation := range a {
+		if v, ok := annotation[key]; ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// Decode decodes the value of key, as returned by Lookup, into the value
+// pointed to by v, so application specific keys can be read into their own
+// types.  It returns false if no annotation contains key.
+func (a Annotations) Decode(key string, v interface{}) (bool, error) {
+	value, ok := a.Lookup(key)
+	if !ok {
+		return false, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(data, v)
+}
+
+// Validate checks that the JSON encoding of the annotations fits the limit
+// of the Weibo API.
+func (a Annotations) Validate() error {
+	_, err := a.encode()
+	return err
+}
+
+// EncodeValues implements query.Encoder, encoding the annotations as a JSON
+// array.
+func (a Annotations) EncodeValues(key string, v *url.Values) error {
+	data, err := a.encode()
+	if err != nil {
+		return err
+	}
+
+	v.Set(key, string(data))
+	return nil
+}
+
+func (a Annotations) encode() ([]byte, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > maxAnnotationsLength {
+		return nil, &AnnotationsLengthError{Length: len(data)}
+	}
+	return data, nil
+}
@@ -0,0 +1,99 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnnotations_EncodeValues(t *testing.T) {
+	a := Annotations{{"app": "bridge", "id": 1}}
+
+	v := url.Values{}
+	if err := a.EncodeValues("annotations", &v); err != nil {
+		t.Errorf("EncodeValues returned error: %v", err)
+	}
+
+	want := `[{"app":"bridge","id":1}]`
+	if got := v.Get("annotations"); got != want {
+		t.Errorf("EncodeValues encoded %q, want %q", got, want)
+	}
+}
+
+func TestAnnotations_Validate(t *testing.T) {
+	if err := (Annotations{{"k": "v"}}).Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+
+	a := Annotations{{"k": strings.Repeat("x", maxAnnotationsLength)}}
+	err := a.Validate()
+	if _, ok := err.(*AnnotationsLengthError); !ok {
+		t.Errorf("Validate returned %#v, want *AnnotationsLengthError", err)
+	}
+}
+
+func TestAnnotations_Decode(t *testing.T) {
+	var a Annotations
+	json.Unmarshal([]byte(`[{"place": {"poiid": "B2094757D06FA3FD4A9C"}}, {"bridge": {"ticket": 7}}]`), &a)
+
+	var ticket struct {
+		Ticket int `json:"ticket"`
+	}
+	ok, err := a.Decode("bridge", &ticket)
+	if err != nil {
+		t.Errorf("Decode returned error: %v", err)
+	}
+	if !ok || ticket.Ticket != 7 {
+		t.Errorf("Decode returned %v, %+v, want true, {Ticket:7}", ok, ticket)
+	}
+
+	if ok, _ := a.Decode("missing", &ticket); ok {
+		t.Errorf("Decode(missing) returned true, want false")
+	}
+}
+
+func TestStatusesCreate_annotations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"status":      "hello",
+			"annotations": `[{"app":"bridge"}]`,
+		})
+		fmt.Fprint(w, `{"id": 1, "annotations": [{"app": "bridge"}]}`)
+	})
+
+	opt := &StatusRequest{Status: String("hello"), Annotations: Annotations{{"app": "bridge"}}}
+	status, _, err := client.Statuses.Create(opt)
+
+	if err != nil {
+		t.Errorf("Statuses.Create returned error: %v", err)
+	}
+
+	want := Annotations{{"app": "bridge"}}
+	if !reflect.DeepEqual(status.Annotations, want) {
+		t.Errorf("Statuses.Create returned annotations %+v, want %+v", status.Annotations, want)
+	}
+}
+
+func TestStatusesCreate_annotationsTooLong(t *testing.T) {
+	setup()
+	defer teardown()
+
+	opt := &StatusRequest{
+		Status:      String("hello"),
+		Annotations: Annotations{{"k": strings.Repeat("x", maxAnnotationsLength)}},
+	}
+	_, _, err := client.Statuses.Create(opt)
+
+	verr, ok := err.(*ValidationError)
+	if !ok || len(verr.Errors) != 1 || verr.Errors[0].Field != "annotations" {
+		t.Errorf("Statuses.Create returned %#v, want *ValidationError for annotations", err)
+	}
+}
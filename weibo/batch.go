@@ -0,0 +1,220 @@
+package weibo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// maxBatchWorkers is the maximum number of batch requests a single ShowMany
+// call has in flight.
+const maxBatchWorkers = 4
+
+// ErrNotFound is reported by ShowMany methods for IDs missing from the
+// responses of Weibo, e.g. those of deleted statuses.
+var ErrNotFound = errors.New("weibo: not found")
+
+// BatchError is returned by ShowMany methods if some IDs failed.
+type BatchError struct {
+	// Errors holds the error of each failed ID.
+	Errors map[int64]error
+}
+
+func (e *BatchError) Error() string {
+	if len(e.Errors) == 0 {
+		return "weibo: batch failed"
+	}
+	ids := make([]int64, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return fmt.Sprintf("weibo: %d IDs failed, first %d: %v", len(ids), ids[0], e.Errors[ids[0]])
+}
+
+// batchKey identifies an ID being fetched from a batch endpoint.
+type batchKey struct {
+	endpoint string
+	id       int64
+}
+
+// batchCall is an ID being fetched, shared by the ShowMany calls waiting
+// for it.  value is the raw JSON object, which each call decodes on its own
+// so that they do not share the result.  abandoned reports whether the
+// fetch failed because the context of the call owning it ended.
+type batchCall struct {
+	done      chan struct{}
+	value     json.RawMessage
+	err       error
+	abandoned bool
+}
+
+// batchFetcher fetches the JSON objects of ids from a batch endpoint, keyed
+// by ID.  IDs missing from the result are not found.
+type batchFetcher func(ctx context.Context, ids []int64) (map[int64]json.RawMessage, error)
+
+// batchFetch fetches the values of ids with fetch, and decodes them into new
+// values of T keyed by ID.  It returns a *BatchError for the IDs which
+// failed.  See Client.fetchRaw for how ids are fetched.
+func batchFetch[T any](ctx context.Context, c *Client, endpoint string, ids []int64, size int, fetch batchFetcher) (map[int64]*T, error) {
+	values := make(map[int64]*T, len(ids))
+	errs := make(map[int64]error)
+
+	for len(ids) > 0 {
+		raw, rawErrs, abandoned := c.fetchRaw(ctx, endpoint, ids, size, fetch)
+		for id, data := range raw {
+			v := new(T)
+			if err := json.Unmarshal(data, v); err != nil {
+				errs[id] = err
+				continue
+			}
+			values[id] = v
+		}
+		for id, err := range rawErrs {
+			errs[id] = err
+		}
+
+		// IDs joined from calls whose context ended are fetched again,
+		// unless the context of this call ended too.
+		ids = abandoned
+		if err := ctx.Err(); err != nil {
+			for _, id := range ids {
+				errs[id] = err
+			}
+			ids = nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return values, &BatchError{Errors: errs}
+	}
+	return values, nil
+}
+
+// fetchRaw fetches the JSON objects of ids from a batch endpoint, calling
+// fetch with chunks of at most size IDs, with at most maxBatchWorkers chunks
+// in flight.  Duplicate IDs, and IDs being fetched by other calls for the
+// same endpoint, are fetched once, and share their errors.  fetchRaw returns
+// the objects and the errors of the IDs, keyed by ID, and the IDs joined
+// from other calls which abandoned them as their context ended.
+func (c *Client) fetchRaw(ctx context.Context, endpoint string, ids []int64, size int, fetch batchFetcher) (map[int64]json.RawMessage, map[int64]error, []int64) {
+	calls := make(map[int64]*batchCall, len(ids))
+	var owned []int64
+	owner := make(map[int64]bool)
+
+	c.batchMu.Lock()
+	if c.batchCalls == nil {
+		c.batchCalls = make(map[batchKey]*batchCall)
+	}
+	for _, id := range ids {
+		if _, ok := calls[id]; ok {
+			continue
+		}
+		key := batchKey{endpoint, id}
+		call, ok := c.batchCalls[key]
+		if !ok {
+			call = &batchCall{done: make(chan struct{})}
+			c.batchCalls[key] = call
+			owned = append(owned, id)
+			owner[id] = true
+		}
+		calls[id] = call
+	}
+	c.batchMu.Unlock()
+
+	var wg sync.WaitGroup
+	workers := make(chan struct{}, maxBatchWorkers)
+	for len(owned) > 0 {
+		n := len(owned)
+		if n > size {
+			n = size
+		}
+		chunk := owned[:n]
+		owned = owned[n:]
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			values, err := map[int64]json.RawMessage(nil), ctx.Err()
+			if err == nil {
+				values, err = fetch(ctx, chunk)
+			}
+
+			c.batchMu.Lock()
+			defer c.batchMu.Unlock()
+			for _, id := range chunk {
+				call := calls[id]
+				if err != nil {
+					call.err = err
+					call.abandoned = ctx.Err() != nil
+				} else if v, ok := values[id]; ok {
+					call.value = v
+				} else {
+					call.err = ErrNotFound
+				}
+				delete(c.batchCalls, batchKey{endpoint, id})
+				close(call.done)
+			}
+		}()
+	}
+
+	values := make(map[int64]json.RawMessage, len(calls))
+	errs := make(map[int64]error)
+	var abandoned []int64
+	for id, call := range calls {
+		select {
+		case <-call.done:
+			switch {
+			case call.abandoned && !owner[id]:
+				abandoned = append(abandoned, id)
+			case call.err != nil:
+				errs[id] = call.err
+			default:
+				values[id] = call.value
+			}
+		case <-ctx.Done():
+			errs[id] = ctx.Err()
+		}
+	}
+	wg.Wait()
+
+	return values, errs, abandoned
+}
+
+// getBatch sends a GET request to the batch endpoint u with ctx, and returns
+// the JSON objects listed under key in its response, keyed by their id.
+func (c *Client) getBatch(ctx context.Context, u, key string) (map[int64]json.RawMessage, error) {
+	req, err := c.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body := make(map[string]json.RawMessage)
+	if _, err := c.Do(req.WithContext(ctx), &body); err != nil {
+		return nil, err
+	}
+	var objects []json.RawMessage
+	if list, ok := body[key]; ok {
+		if err := json.Unmarshal(list, &objects); err != nil {
+			return nil, err
+		}
+	}
+
+	values := make(map[int64]json.RawMessage, len(objects))
+	for _, object := range objects {
+		var id struct {
+			ID int64 `json:"id"`
+		}
+		if err := json.Unmarshal(object, &id); err != nil {
+			return nil, err
+		}
+		values[id.ID] = object
+	}
+	return values, nil
+}
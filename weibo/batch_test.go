@@ -0,0 +1,266 @@
+package weibo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStatusesShowMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	var batches []int
+	inFlight, maxInFlight := 0, 0
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.FormValue("ids"), ",")
+		mu.Lock()
+		batches = append(batches, len(ids))
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(5 * time.Millisecond)
+		var statuses []string
+		for _, id := range ids {
+			if id != "7" {
+				statuses = append(statuses, fmt.Sprintf(`{"id":%v}`, id))
+			}
+		}
+		fmt.Fprintf(w, `{"statuses":[%v]}`, strings.Join(statuses, ","))
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	ids := make([]int64, 0, 520)
+	for i := 1; i <= 500; i++ {
+		ids = append(ids, int64(i))
+	}
+	ids = append(ids, 1, 2, 3)
+
+	statuses, err := client.Statuses.ShowMany(context.Background(), ids)
+
+	if len(statuses) != 499 {
+		t.Errorf("Statuses.ShowMany returned %d statuses, want 499", len(statuses))
+	}
+	if s := statuses[42]; s == nil || *s.ID != 42 {
+		t.Errorf("Statuses.ShowMany returned %+v for ID 42", s)
+	}
+	if batchErr, ok := err.(*BatchError); !ok || len(batchErr.Errors) != 1 || batchErr.Errors[7] != ErrNotFound {
+		t.Errorf("Statuses.ShowMany returned error %v, want ErrNotFound for ID 7", err)
+	}
+	if len(batches) != 10 {
+		t.Errorf("sent %d batches, want 10", len(batches))
+	}
+	for _, n := range batches {
+		if n > maxShowBatchIDs {
+			t.Errorf("sent a batch of %d IDs, want at most %d", n, maxShowBatchIDs)
+		}
+	}
+	if maxInFlight > maxBatchWorkers {
+		t.Errorf("%d batches in flight, want at most %d", maxInFlight, maxBatchWorkers)
+	}
+}
+
+func TestStatusesShowMany_coalesce(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	fetched := make(map[string]int)
+	release := make(chan struct{})
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		ids := strings.Split(r.FormValue("ids"), ",")
+		mu.Lock()
+		for _, id := range ids {
+			fetched[id]++
+		}
+		mu.Unlock()
+
+		<-release
+		var statuses []string
+		for _, id := range ids {
+			statuses = append(statuses, fmt.Sprintf(`{"id":%v}`, id))
+		}
+		fmt.Fprintf(w, `{"statuses":[%v]}`, strings.Join(statuses, ","))
+	})
+
+	var wg sync.WaitGroup
+	var first map[int64]*Status
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		first, _ = client.Statuses.ShowMany(context.Background(), []int64{1, 2})
+	}()
+
+	// waitInFlight waits until n IDs are being fetched.
+	waitInFlight := func(n int) {
+		for {
+			client.batchMu.Lock()
+			m := len(client.batchCalls)
+			client.batchMu.Unlock()
+			if m == n {
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	waitInFlight(2)
+
+	var second map[int64]*Status
+	var err error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		second, err = client.Statuses.ShowMany(context.Background(), []int64{2, 3})
+	}()
+	waitInFlight(3)
+	close(release)
+	wg.Wait()
+
+	if err != nil {
+		t.Errorf("Statuses.ShowMany returned error: %v", err)
+	}
+	if len(first) != 2 || len(second) != 2 || second[2] == nil || second[3] == nil {
+		t.Errorf("Statuses.ShowMany returned %v and %v", first, second)
+	}
+	if fetched["2"] != 1 {
+		t.Errorf("ID 2 fetched %d times, want 1", fetched["2"])
+	}
+	if first[2] == second[2] {
+		t.Error("Statuses.ShowMany calls share the status of ID 2")
+	}
+	if len(client.batchCalls) != 0 {
+		t.Errorf("%d IDs still in flight", len(client.batchCalls))
+	}
+}
+
+func TestStatusesShowMany_canceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent with a canceled context")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	statuses, err := client.Statuses.ShowMany(ctx, []int64{1, 2})
+	if len(statuses) != 0 {
+		t.Errorf("Statuses.ShowMany returned %v, want none", statuses)
+	}
+	if batchErr, ok := err.(*BatchError); !ok || batchErr.Errors[1] != context.Canceled {
+		t.Errorf("Statuses.ShowMany returned error %v, want context.Canceled per ID", err)
+	}
+}
+
+func TestStatusesShowMany_joinCanceled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	requests := 0
+	block := make(chan struct{})
+	defer close(block)
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			select {
+			case <-block:
+			case <-r.Context().Done():
+			}
+			return
+		}
+		fmt.Fprint(w, `{"statuses":[{"id":1}]}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.Statuses.ShowMany(ctx, []int64{1})
+		done <- err
+	}()
+	for {
+		client.batchMu.Lock()
+		n := len(client.batchCalls)
+		client.batchMu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// join the fetch of the first call, then cancel it
+	joined := make(chan struct{})
+	var statuses map[int64]*Status
+	var err error
+	go func() {
+		statuses, err = client.Statuses.ShowMany(context.Background(), []int64{1})
+		close(joined)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	if err := <-done; err == nil {
+		t.Error("canceled Statuses.ShowMany returned no error")
+	}
+	<-joined
+	if err != nil {
+		t.Errorf("Statuses.ShowMany with a live context returned error: %v", err)
+	}
+	if s := statuses[1]; s == nil || *s.ID != 1 {
+		t.Errorf("Statuses.ShowMany returned %v, want status 1", statuses)
+	}
+}
+
+func TestBatchError_Error(t *testing.T) {
+	if got := (&BatchError{}).Error(); got == "" {
+		t.Error("BatchError.Error returned the empty string")
+	}
+
+	err := &BatchError{Errors: map[int64]error{3: ErrNotFound, 2: ErrNotFound}}
+	if got, want := err.Error(), "weibo: 2 IDs failed, first 2: weibo: not found"; got != want {
+		t.Errorf("BatchError.Error = %q, want %q", got, want)
+	}
+}
+
+func TestStatusesShowMany_timeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	requests := 0
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	})
+	client.SetHTTPClient(&http.Client{Timeout: 10 * time.Millisecond})
+
+	_, err := client.Statuses.ShowMany(context.Background(), []int64{1})
+	if err == nil {
+		t.Error("Statuses.ShowMany returned no error for a timed out request")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests != 1 {
+		t.Errorf("Statuses.ShowMany sent %d requests, want 1", requests)
+	}
+}
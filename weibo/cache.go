@@ -0,0 +1,218 @@
+package weibo
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A Cache stores the responses to GET requests, see Client.Cache.  Keys
+// start with the endpoint name of the request, such as
+// "statuses/show", followed by a space.  Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, unless it expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value for key, for ttl.
+	Set(key string, value []byte, ttl time.Duration)
+
+	// DeletePrefix deletes the values of all keys starting with prefix.
+	DeletePrefix(prefix string)
+}
+
+// relatedResources are the resources whose cached responses are invalidated
+// by writes to each resource, besides the resource itself.
+var relatedResources = map[string][]string{
+	"statuses":    {"users"},
+	"comments":    {"statuses"},
+	"favorites":   {"statuses"},
+	"friendships": {"users"},
+}
+
+// cachedResponse is a response stored in a Cache.
+type cachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// cacheTTL returns how long the responses of endpoint are cached by c.
+func (c *Client) cacheTTL(endpoint string) time.Duration {
+	if ttl, ok := c.EndpointCacheTTLs[endpoint]; ok {
+		return ttl
+	}
+	return c.CacheTTL
+}
+
+// cacheKey returns the Cache key of req, sent with the access token of c
+// unless it has an access_token parameter.  The token is hashed, and the
+// parameter removed from the URL, so that it is not stored in the Cache.
+func (c *Client) cacheKey(req *http.Request) string {
+	token := c.accessToken
+	u := *req.URL
+	q := u.Query()
+	if _, ok := q[accessTokenParam]; ok {
+		token = q.Get(accessTokenParam)
+		q.Del(accessTokenParam)
+		u.RawQuery = q.Encode()
+	}
+	sum := sha256.Sum256([]byte(token))
+	return Endpoint(req) + " " + hex.EncodeToString(sum[:8]) + " " + u.String()
+}
+
+// cachedResponse returns the response to req stored in the Cache of c under
+// key, if any.
+func (c *Client) cachedResponse(req *http.Request, key string) (*Response, bool) {
+	data, ok := c.Cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	cached := new(cachedResponse)
+	if err := json.Unmarshal(data, cached); err != nil {
+		return nil, false
+	}
+
+	response := newResponse(&http.Response{
+		Status:        http.StatusText(cached.StatusCode),
+		StatusCode:    cached.StatusCode,
+		Header:        cached.Header,
+		Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	})
+	response.FromCache = true
+	return response, true
+}
+
+// cacheResponse stores resp in the Cache of c under key, leaving its body
+// unread.
+func (c *Client) cacheResponse(key string, resp *http.Response, ttl time.Duration) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	data, err := json.Marshal(&cachedResponse{StatusCode: resp.StatusCode, Header: resp.Header, Body: body})
+	if err != nil {
+		return err
+	}
+	c.Cache.Set(key, data, ttl)
+	return nil
+}
+
+// invalidateCache deletes the cached responses which a write to endpoint may
+// have made stale, i.e. those of the same and related resources, including
+// the endpoints at their root such as "tags".
+func (c *Client) invalidateCache(endpoint string) {
+	resource, _, _ := strings.Cut(endpoint, "/")
+	for _, r := range append([]string{resource}, relatedResources[resource]...) {
+		c.Cache.DeletePrefix(r + " ")
+		c.Cache.DeletePrefix(r + "/")
+	}
+}
+
+// LRUCache is an in-memory Cache holding a limited number of values, which
+// evicts the least recently used ones.  It is safe for concurrent use.
+type LRUCache struct {
+	size int
+
+	// now is the clock entries expire by.
+	now func() time.Time
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+// lruEntry is a value stored in an LRUCache.
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache returns an LRUCache holding at most size values, and at least
+// one.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{
+		size:  size,
+		now:   time.Now,
+		ll:    list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+// Get implements Cache.
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	e, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*lruEntry)
+	if !l.now().Before(entry.expires) {
+		l.remove(e)
+		return nil, false
+	}
+	l.ll.MoveToFront(e)
+	return entry.value, true
+}
+
+// Set implements Cache.
+func (l *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expires := l.now().Add(ttl)
+	if e, ok := l.items[key]; ok {
+		entry := e.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		l.ll.MoveToFront(e)
+		return
+	}
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for l.ll.Len() > l.size {
+		l.remove(l.ll.Back())
+	}
+}
+
+// DeletePrefix implements Cache.
+func (l *LRUCache) DeletePrefix(prefix string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, e := range l.items {
+		if strings.HasPrefix(key, prefix) {
+			l.remove(e)
+		}
+	}
+}
+
+// Len returns the number of values in l, including expired ones not yet
+// evicted.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+// remove removes e from l.  l.mu must be held.
+func (l *LRUCache) remove(e *list.Element) {
+	l.ll.Remove(e)
+	delete(l.items, e.Value.(*lruEntry).key)
+}
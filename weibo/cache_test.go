@@ -0,0 +1,215 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_Cache(t *testing.T) {
+	setup()
+	defer teardown()
+
+	hits := make(map[string]int)
+	mux.HandleFunc("/2/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		hits[r.FormValue("id")]++
+		fmt.Fprintf(w, `{"id":%v}`, r.FormValue("id"))
+	})
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":3}`)
+	})
+
+	client.Cache = NewLRUCache(10)
+	client.CacheTTL = time.Minute
+
+	for i := 0; i < 3; i++ {
+		status, resp, err := client.Statuses.ShowLongText(1)
+		if err != nil {
+			t.Fatalf("Statuses.ShowLongText returned error: %v", err)
+		}
+		if *status.ID != 1 {
+			t.Errorf("Statuses.ShowLongText returned ID %v, want 1", *status.ID)
+		}
+		if want := i > 0; resp.FromCache != want || resp.StatusCode != 200 {
+			t.Errorf("response %d FromCache = %v, StatusCode = %v, want %v, 200", i, resp.FromCache, resp.StatusCode, want)
+		}
+	}
+	client.Statuses.ShowLongText(2)
+	if hits["1"] != 1 || hits["2"] != 1 {
+		t.Errorf("server hits = %v, want one per ID", hits)
+	}
+
+	// another token does not share the cached responses
+	other := NewClient("456")
+	other.BaseURL, other.Cache, other.CacheTTL = client.BaseURL, client.Cache, client.CacheTTL
+	other.Statuses.ShowLongText(1)
+	if hits["1"] != 2 {
+		t.Errorf("server hits for ID 1 = %v, want 2", hits["1"])
+	}
+
+	// writes invalidate the statuses
+	client.Statuses.Create(&StatusRequest{Status: String("hi")})
+	_, resp, _ := client.Statuses.ShowLongText(1)
+	if resp.FromCache || hits["1"] != 3 {
+		t.Errorf("response after write FromCache = %v, server hits = %v", resp.FromCache, hits["1"])
+	}
+}
+
+func TestClient_Cache_endpointTTLs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var hits int
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		fmt.Fprint(w, `{"status":1}`)
+	})
+	mux.HandleFunc("/2/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error_code":20101}`)
+	})
+
+	client.Cache = NewLRUCache(10)
+	client.CacheTTL = time.Minute
+	client.EndpointCacheTTLs = map[string]time.Duration{"remind/unread_count": 0}
+
+	client.Remind.UnreadCount("1")
+	client.Remind.UnreadCount("1")
+	if hits != 2 {
+		t.Errorf("server hits = %v, want 2 for an uncached endpoint", hits)
+	}
+
+	client.Statuses.ShowLongText(1)
+	_, resp, err := client.Statuses.ShowLongText(1)
+	if err == nil || resp.FromCache {
+		t.Errorf("failed response was cached")
+	}
+}
+
+func TestClient_Cache_keyHidesToken(t *testing.T) {
+	c := NewClient("secret")
+	req, _ := c.NewRequest("GET", "statuses/show.json?id=1", nil)
+	key := c.cacheKey(req)
+	if want := "statuses/show "; !strings.HasPrefix(key, want) {
+		t.Errorf("cacheKey = %q, want prefix %q", key, want)
+	}
+	if strings.Contains(key, "secret") {
+		t.Errorf("cacheKey = %q contains the access token", key)
+	}
+}
+
+func TestClient_Cache_keyStripsTokenParam(t *testing.T) {
+	c := NewClient("123")
+	req, _ := c.NewRequest("GET", "statuses/show.json?id=1&access_token=secret", nil)
+	other, _ := c.NewRequest("GET", "statuses/show.json?id=1&access_token=other", nil)
+
+	key := c.cacheKey(req)
+	if strings.Contains(key, "secret") || strings.Contains(key, "access_token") {
+		t.Errorf("cacheKey = %q contains the access token", key)
+	}
+	if key == c.cacheKey(other) {
+		t.Errorf("cacheKey = %q for requests with different tokens", key)
+	}
+}
+
+func TestClient_Cache_invalidateResourceRoot(t *testing.T) {
+	setup()
+	defer teardown()
+
+	tags := `[{"1":"music"}]`
+	favorites := `{"favorites":[]}`
+	mux.HandleFunc("/2/tags.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, tags)
+	})
+	mux.HandleFunc("/2/tags/create.json", func(w http.ResponseWriter, r *http.Request) {
+		tags = `[{"1":"music"},{"2":"travel"}]`
+		fmt.Fprint(w, `[{"tagid":2}]`)
+	})
+	mux.HandleFunc("/2/favorites.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, favorites)
+	})
+	mux.HandleFunc("/2/favorites/create.json", func(w http.ResponseWriter, r *http.Request) {
+		favorites = `{"favorites":[{"status":{"id":1}}]}`
+		fmt.Fprint(w, `{"status":{"id":1}}`)
+	})
+
+	client.Cache = NewLRUCache(10)
+	client.CacheTTL = time.Minute
+
+	client.Tags.List(&TagListOptions{UID: "1"})
+	if _, _, err := client.Tags.Create(&TagRequest{Tags: []string{"travel"}}); err != nil {
+		t.Fatalf("Tags.Create returned error: %v", err)
+	}
+	list, resp, err := client.Tags.List(&TagListOptions{UID: "1"})
+	if err != nil {
+		t.Fatalf("Tags.List returned error: %v", err)
+	}
+	if resp.FromCache || len(list) != 2 {
+		t.Errorf("Tags.List after Tags.Create returned %d tags, FromCache = %v, want 2 fresh tags", len(list), resp.FromCache)
+	}
+
+	fetchFavorites := func() (string, *Response) {
+		req, _ := client.NewRequest("GET", "favorites.json", nil)
+		buf := new(strings.Builder)
+		resp, err := client.Do(req, buf)
+		if err != nil {
+			t.Fatalf("favorites returned error: %v", err)
+		}
+		return buf.String(), resp
+	}
+	fetchFavorites()
+	req, _ := client.NewRequest("POST", "favorites/create.json", &struct {
+		ID int64 `url:"id"`
+	}{1})
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("favorites/create returned error: %v", err)
+	}
+	if body, resp := fetchFavorites(); resp.FromCache || body != favorites {
+		t.Errorf("favorites after favorites/create returned %s, FromCache = %v, want %s", body, resp.FromCache, favorites)
+	}
+}
+
+func TestNewLRUCache_size(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		l := NewLRUCache(size)
+		l.Set("a", []byte("a"), time.Minute)
+		l.Set("b", []byte("b"), time.Minute)
+		if l.Len() != 1 {
+			t.Errorf("NewLRUCache(%d) holds %d values, want 1", size, l.Len())
+		}
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	clock := &fakeClock{t: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewLRUCache(2)
+	l.now = clock.now
+
+	l.Set("statuses/show a", []byte("a"), time.Minute)
+	l.Set("statuses/show b", []byte("b"), time.Minute)
+	l.Get("statuses/show a")
+	l.Set("users/show c", []byte("c"), time.Hour)
+
+	if _, ok := l.Get("statuses/show b"); ok {
+		t.Error("least recently used value was not evicted")
+	}
+	if v, ok := l.Get("statuses/show a"); !ok || string(v) != "a" {
+		t.Errorf("Get returned %q, %v, want %q, true", v, ok, "a")
+	}
+
+	clock.t = clock.t.Add(time.Minute)
+	if _, ok := l.Get("statuses/show a"); ok {
+		t.Error("expired value was returned")
+	}
+	if _, ok := l.Get("users/show c"); !ok {
+		t.Error("unexpired value was not returned")
+	}
+
+	l.Set("statuses/show d", []byte("d"), time.Minute)
+	l.DeletePrefix("statuses/")
+	if _, ok := l.Get("statuses/show d"); ok || l.Len() != 1 {
+		t.Errorf("DeletePrefix left %d values, want 1", l.Len())
+	}
+}
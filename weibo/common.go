@@ -0,0 +1,176 @@
+package weibo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sync"
+)
+
+// CommonService handles communication with the common related
+// methods of the Weibo API, which look up region and timezone codes.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/common/get_province
+type CommonService struct {
+	client *Client
+}
+
+// Region represents a country, province or city code and its name.
+type Region struct {
+	Code string
+	Name string
+}
+
+// UnmarshalJSON decodes a region as returned by the Weibo API, which is an
+// object with the code as its only key, i.e. {"001011": "北京"}.
+func (r *Region) UnmarshalJSON(data []byte) error {
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	for code, name := range m {
+		r.Code, r.Name = code, name
+	}
+
+	return nil
+}
+
+// CommonOptions specifies the optional parameters to the CommonService
+// methods.
+type CommonOptions struct {
+	// Capital filters by the first letter of the name, e.g. "a".
+	Capital string `url:"capital,omitempty"`
+
+	// Language of the names, one of "zh-cn", "zh-tw" or "english".
+	// Defaults to "zh-cn".
+	Language string `url:"language,omitempty"`
+}
+
+// regionOptions specifies the parameters to the CommonService.GetProvince
+// and CommonService.GetCity methods.
+type regionOptions struct {
+	Country  string `url:"country,omitempty"`
+	Province string `url:"province,omitempty"`
+	CommonOptions
+}
+
+// GetCountry lists the countries.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/common/get_country
+func (s *CommonService) GetCountry(opt *CommonOptions) ([]Region, *Response, error) {
+	return s.regions("common/get_country.json", newRegionOptions(opt))
+}
+
+// GetProvince lists the provinces of a country, e.g. "001" for China.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/common/get_province
+func (s *CommonService) GetProvince(country string, opt *CommonOptions) ([]Region, *Response, error) {
+	o := newRegionOptions(opt)
+	o.Country = country
+	return s.regions("common/get_province.json", o)
+}
+
+// GetCity lists the cities of a province, e.g. "001011" for Beijing.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/common/get_city
+func (s *CommonService) GetCity(province string, opt *CommonOptions) ([]Region, *Response, error) {
+	o := newRegionOptions(opt)
+	o.Province = province
+	return s.regions("common/get_city.json", o)
+}
+
+// GetTimezone returns the timezone names keyed by their UTC offset.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/common/get_timezone
+func (s *CommonService) GetTimezone(opt *CommonOptions) (map[string]string, *Response, error) {
+	u, err := addOptions("common/get_timezone.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timezones := make(map[string]string)
+	resp, err := s.client.Do(req, &timezones)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timezones, resp, err
+}
+
+func newRegionOptions(opt *CommonOptions) *regionOptions {
+	o := new(regionOptions)
+	if opt != nil {
+		o.CommonOptions = *opt
+	}
+	return o
+}
+
+// regions sends a GET request to u with opt and decodes the returned regions.
+func (s *CommonService) regions(u string, opt *regionOptions) ([]Region, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	regions := new([]Region)
+	resp, err := s.client.Do(req, regions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *regions, resp, err
+}
+
+// regionsJSON is an offline snapshot of the Chinese province and city codes
+// used by User.Province and User.City, keyed by province code.
+//
+//go:embed regions.json
+var regionsJSON []byte
+
+type regionTable map[string]struct {
+	Name   string            `json:"name"`
+	Cities map[string]string `json:"cities"`
+}
+
+var (
+	regionsOnce sync.Once
+	regionCodes regionTable
+)
+
+func loadRegions() regionTable {
+	regionsOnce.Do(func() {
+		if err := json.Unmarshal(regionsJSON, &regionCodes); err != nil {
+			panic("weibo: invalid embedded regions.json: " + err.Error())
+		}
+	})
+	return regionCodes
+}
+
+// ProvinceName returns the name of the user's province, looked up in an
+// embedded code table.  It returns the empty string if the province is
+// unknown.
+func (u *User) ProvinceName() string {
+	if u.Province == nil {
+		return ""
+	}
+	return loadRegions()[*u.Province].Name
+}
+
+// CityName returns the name of the user's city, looked up in an embedded
+// code table.  It returns the empty string if the city is unknown.
+func (u *User) CityName() string {
+	if u.Province == nil || u.City == nil {
+		return ""
+	}
+	return loadRegions()[*u.Province].Cities[*u.City]
+}
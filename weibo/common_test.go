@@ -0,0 +1,124 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCommonGetCountry(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/common/get_country.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"language": "english",
+		})
+		fmt.Fprint(w, `[{"001": "China"}, {"002": "Albania"}]`)
+	})
+
+	countries, _, err := client.Common.GetCountry(&CommonOptions{Language: "english"})
+
+	if err != nil {
+		t.Errorf("Common.GetCountry returned error: %v", err)
+	}
+
+	want := []Region{{Code: "001", Name: "China"}, {Code: "002", Name: "Albania"}}
+	if !reflect.DeepEqual(countries, want) {
+		t.Errorf("Common.GetCountry returned %+v, want %+v", countries, want)
+	}
+}
+
+func TestCommonGetProvince(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/common/get_province.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"country": "001",
+		})
+		fmt.Fprint(w, `[{"001011": "北京"}, {"001012": "天津"}]`)
+	})
+
+	provinces, _, err := client.Common.GetProvince("001", nil)
+
+	if err != nil {
+		t.Errorf("Common.GetProvince returned error: %v", err)
+	}
+
+	want := []Region{{Code: "001011", Name: "北京"}, {Code: "001012", Name: "天津"}}
+	if !reflect.DeepEqual(provinces, want) {
+		t.Errorf("Common.GetProvince returned %+v, want %+v", provinces, want)
+	}
+}
+
+func TestCommonGetCity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/common/get_city.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"province": "001011",
+			"capital":  "h",
+		})
+		fmt.Fprint(w, `[{"001011008": "海淀区"}]`)
+	})
+
+	cities, _, err := client.Common.GetCity("001011", &CommonOptions{Capital: "h"})
+
+	if err != nil {
+		t.Errorf("Common.GetCity returned error: %v", err)
+	}
+
+	want := []Region{{Code: "001011008", Name: "海淀区"}}
+	if !reflect.DeepEqual(cities, want) {
+		t.Errorf("Common.GetCity returned %+v, want %+v", cities, want)
+	}
+}
+
+func TestCommonGetTimezone(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/common/get_timezone.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"8": "(GMT+08:00) 北京"}`)
+	})
+
+	timezones, _, err := client.Common.GetTimezone(nil)
+
+	if err != nil {
+		t.Errorf("Common.GetTimezone returned error: %v", err)
+	}
+
+	want := map[string]string{"8": "(GMT+08:00) 北京"}
+	if !reflect.DeepEqual(timezones, want) {
+		t.Errorf("Common.GetTimezone returned %+v, want %+v", timezones, want)
+	}
+}
+
+func TestUser_RegionNames(t *testing.T) {
+	tests := []struct {
+		user           *User
+		province, city string
+	}{
+		{&User{Province: String("11"), City: String("8")}, "北京", "海淀区"},
+		{&User{Province: String("44"), City: String("3")}, "广东", "深圳"},
+		{&User{Province: String("400"), City: String("1")}, "海外", ""},
+		{&User{Province: String("999")}, "", ""},
+		{&User{}, "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.user.ProvinceName(); got != tt.province {
+			t.Errorf("ProvinceName() = %q, want %q", got, tt.province)
+		}
+		if got := tt.user.CityName(); got != tt.city {
+			t.Errorf("CityName() = %q, want %q", got, tt.city)
+		}
+	}
+}
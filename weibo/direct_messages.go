@@ -0,0 +1,278 @@
+package weibo
+
+// DirectMessagesService handles communication with the direct message
+// related methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages
+type DirectMessagesService struct {
+	client *Client
+}
+
+// DirectMessage represents a private message between two Weibo users.
+type DirectMessage struct {
+	ID                  *int64  `json:"id,omitempty"`
+	IDStr               *string `json:"idstr,omitempty"`
+	MID                 *string `json:"mid,omitempty"`
+	CreatedAt           *string `json:"created_at,omitempty"`
+	Text                *string `json:"text,omitempty"`
+	SenderID            *int64  `json:"sender_id,omitempty"`
+	RecipientID         *int64  `json:"recipient_id,omitempty"`
+	SenderScreenName    *string `json:"sender_screen_name,omitempty"`
+	RecipientScreenName *string `json:"recipient_screen_name,omitempty"`
+	Sender              *User   `json:"sender,omitempty"`
+	Recipient           *User   `json:"recipient,omitempty"`
+}
+
+// DirectMessages represents a set of direct messages.
+type DirectMessages struct {
+	DirectMessages []DirectMessage `json:"direct_messages,omitempty"`
+	TotalNumber    *int            `json:"total_number,omitempty"`
+	PreviousCursor *int            `json:"previous_cursor,omitempty"`
+	NextCursor     *int            `json:"next_cursor,omitempty"`
+}
+
+// DirectMessageContact represents a user the authenticated user has
+// exchanged direct messages with, along with the latest message.
+type DirectMessageContact struct {
+	User          *User          `json:"user,omitempty"`
+	DirectMessage *DirectMessage `json:"direct_message,omitempty"`
+}
+
+// DirectMessageContacts represents a set of direct message contacts.
+type DirectMessageContacts struct {
+	UserList       []DirectMessageContact `json:"user_list,omitempty"`
+	TotalNumber    *int                   `json:"total_number,omitempty"`
+	PreviousCursor *int                   `json:"previous_cursor,omitempty"`
+	NextCursor     *int                   `json:"next_cursor,omitempty"`
+}
+
+// DirectMessageListOptions specifies the optional parameters to the
+// DirectMessagesService.List, DirectMessagesService.Sent and
+// DirectMessagesService.Conversation methods.
+type DirectMessageListOptions struct {
+	SinceID string `url:"since_id,omitempty"`
+	MaxID   string `url:"max_id,omitempty"`
+	ListOptions
+}
+
+// CursorOptions specifies the optional parameters to methods that support
+// cursor based pagination.
+type CursorOptions struct {
+	Count  int `url:"count,omitempty"`
+	Cursor int `url:"cursor,omitempty"`
+}
+
+// DirectMessageRequest represents a request to send a direct message.
+// Either UID or ScreenName identifies the recipient.
+type DirectMessageRequest struct {
+	Text       *string `url:"text"`
+	UID        *string `url:"uid,omitempty"`
+	ScreenName *string `url:"screen_name,omitempty"`
+
+	// ID of a status to attach to the message.
+	ID *int64 `url:"id,omitempty"`
+}
+
+// conversationOptions specifies the parameters to the
+// DirectMessagesService.Conversation method.
+type conversationOptions struct {
+	UID string `url:"uid"`
+	DirectMessageListOptions
+}
+
+// directMessageIDs specifies the parameters to the batch methods of
+// DirectMessagesService.
+type directMessageIDs struct {
+	IDs []int64 `url:"dmids,comma"`
+}
+
+// directMessageDestroyRequest represents a request to destroy direct
+// messages.
+type directMessageDestroyRequest struct {
+	ID  int64   `url:"id,omitempty"`
+	IDs []int64 `url:"ids,comma,omitempty"`
+}
+
+// isCapableOptions specifies the parameters to the
+// DirectMessagesService.IsCapable method.
+type isCapableOptions struct {
+	UID string `url:"uid"`
+}
+
+// isCapable represents the result of the DirectMessagesService.IsCapable
+// method.
+type isCapable struct {
+	Result bool `json:"result"`
+}
+
+// List the direct messages received by the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages
+func (s *DirectMessagesService) List(opt *DirectMessageListOptions) (*DirectMessages, *Response, error) {
+	u, err := addOptions("direct_messages.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.messages(u)
+}
+
+// Sent lists the direct messages sent by the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/sent
+func (s *DirectMessagesService) Sent(opt *DirectMessageListOptions) (*DirectMessages, *Response, error) {
+	u, err := addOptions("direct_messages/sent.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.messages(u)
+}
+
+// Conversation lists the direct messages exchanged between the
+// authenticated user and another user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/conversation
+func (s *DirectMessagesService) Conversation(uid string, opt *DirectMessageListOptions) (*DirectMessages, *Response, error) {
+	o := &conversationOptions{UID: uid}
+	if opt != nil {
+		o.DirectMessageListOptions = *opt
+	}
+
+	u, err := addOptions("direct_messages/conversation.json", o)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.messages(u)
+}
+
+// UserList lists the users the authenticated user has exchanged direct
+// messages with.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/user_list
+func (s *DirectMessagesService) UserList(opt *CursorOptions) (*DirectMessageContacts, *Response, error) {
+	u, err := addOptions("direct_messages/user_list.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contacts := new(DirectMessageContacts)
+	resp, err := s.client.Do(req, contacts)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return contacts, resp, err
+}
+
+// ShowBatch returns direct messages by their IDs.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/show_batch
+func (s *DirectMessagesService) ShowBatch(ids []int64) ([]DirectMessage, *Response, error) {
+	u, err := addOptions("direct_messages/show_batch.json", &directMessageIDs{IDs: ids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := new([]DirectMessage)
+	resp, err := s.client.Do(req, messages)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *messages, resp, err
+}
+
+// IsCapable checks whether the authenticated user can send direct messages
+// to a user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/is_capable
+func (s *DirectMessagesService) IsCapable(uid string) (bool, *Response, error) {
+	u, err := addOptions("direct_messages/is_capable.json", &isCapableOptions{UID: uid})
+	if err != nil {
+		return false, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	result := new(isCapable)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return false, resp, err
+	}
+
+	return result.Result, resp, err
+}
+
+// Create sends a direct message.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/new
+func (s *DirectMessagesService) Create(opt *DirectMessageRequest) (*DirectMessage, *Response, error) {
+	return s.postMessage("direct_messages/new.json", opt)
+}
+
+// Destroy a direct message.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/destroy
+func (s *DirectMessagesService) Destroy(id int64) (*DirectMessage, *Response, error) {
+	return s.postMessage("direct_messages/destroy.json", &directMessageDestroyRequest{ID: id})
+}
+
+// DestroyBatch destroys direct messages in batch.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/direct_messages/destroy_batch
+func (s *DirectMessagesService) DestroyBatch(ids []int64) (*Response, error) {
+	u := "direct_messages/destroy_batch.json"
+
+	req, err := s.client.NewRequest("POST", u, &directMessageDestroyRequest{IDs: ids})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// messages sends a GET request to u and decodes the returned direct
+// messages.
+func (s *DirectMessagesService) messages(u string) (*DirectMessages, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := new(DirectMessages)
+	resp, err := s.client.Do(req, messages)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return messages, resp, err
+}
+
+// postMessage sends a POST request to u and decodes the returned direct
+// message.
+func (s *DirectMessagesService) postMessage(u string, body interface{}) (*DirectMessage, *Response, error) {
+	req, err := s.client.NewRequest("POST", u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	message := new(DirectMessage)
+	resp, err := s.client.Do(req, message)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return message, resp, err
+}
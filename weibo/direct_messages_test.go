@@ -0,0 +1,222 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestDirectMessagesList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"since_id": "10",
+		})
+		fmt.Fprint(w, `{"direct_messages": [{"id": 11, "text": "hi", "created_at": "Tue Oct 19 10:00:00 +0800 2026", "sender": {"id": 1}, "recipient": {"id": 2}}], "total_number": 1}`)
+	})
+
+	messages, _, err := client.DirectMessages.List(&DirectMessageListOptions{SinceID: "10"})
+
+	if err != nil {
+		t.Errorf("DirectMessages.List returned error: %v", err)
+	}
+
+	want := &DirectMessages{
+		DirectMessages: []DirectMessage{{
+			ID:        Int64(11),
+			Text:      String("hi"),
+			CreatedAt: String("Tue Oct 19 10:00:00 +0800 2026"),
+			Sender:    &User{ID: Int(1)},
+			Recipient: &User{ID: Int(2)},
+		}},
+		TotalNumber: Int(1),
+	}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("DirectMessages.List returned %+v, want %+v", messages, want)
+	}
+}
+
+func TestDirectMessagesSent(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/sent.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"direct_messages": [{"id": 11}], "total_number": 1}`)
+	})
+
+	messages, _, err := client.DirectMessages.Sent(nil)
+
+	if err != nil {
+		t.Errorf("DirectMessages.Sent returned error: %v", err)
+	}
+
+	want := []DirectMessage{{ID: Int64(11)}}
+	if !reflect.DeepEqual(messages.DirectMessages, want) {
+		t.Errorf("DirectMessages.Sent returned %+v, want %+v", messages.DirectMessages, want)
+	}
+}
+
+func TestDirectMessagesConversation(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/conversation.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uid":    "42",
+			"max_id": "20",
+		})
+		fmt.Fprint(w, `{"direct_messages": [{"id": 11}], "total_number": 1}`)
+	})
+
+	messages, _, err := client.DirectMessages.Conversation("42", &DirectMessageListOptions{MaxID: "20"})
+
+	if err != nil {
+		t.Errorf("DirectMessages.Conversation returned error: %v", err)
+	}
+
+	want := []DirectMessage{{ID: Int64(11)}}
+	if !reflect.DeepEqual(messages.DirectMessages, want) {
+		t.Errorf("DirectMessages.Conversation returned %+v, want %+v", messages.DirectMessages, want)
+	}
+}
+
+func TestDirectMessagesUserList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/user_list.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"count": "10",
+		})
+		fmt.Fprint(w, `{"user_list": [{"user": {"id": 42}, "direct_message": {"id": 11}}], "total_number": 1}`)
+	})
+
+	contacts, _, err := client.DirectMessages.UserList(&CursorOptions{Count: 10})
+
+	if err != nil {
+		t.Errorf("DirectMessages.UserList returned error: %v", err)
+	}
+
+	want := []DirectMessageContact{{User: &User{ID: Int(42)}, DirectMessage: &DirectMessage{ID: Int64(11)}}}
+	if !reflect.DeepEqual(contacts.UserList, want) {
+		t.Errorf("DirectMessages.UserList returned %+v, want %+v", contacts.UserList, want)
+	}
+}
+
+func TestDirectMessagesShowBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"dmids": "11,12",
+		})
+		fmt.Fprint(w, `[{"id": 11}, {"id": 12}]`)
+	})
+
+	messages, _, err := client.DirectMessages.ShowBatch([]int64{11, 12})
+
+	if err != nil {
+		t.Errorf("DirectMessages.ShowBatch returned error: %v", err)
+	}
+
+	want := []DirectMessage{{ID: Int64(11)}, {ID: Int64(12)}}
+	if !reflect.DeepEqual(messages, want) {
+		t.Errorf("DirectMessages.ShowBatch returned %+v, want %+v", messages, want)
+	}
+}
+
+func TestDirectMessagesIsCapable(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/is_capable.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uid": "42",
+		})
+		fmt.Fprint(w, `{"result": true}`)
+	})
+
+	capable, _, err := client.DirectMessages.IsCapable("42")
+
+	if err != nil {
+		t.Errorf("DirectMessages.IsCapable returned error: %v", err)
+	}
+
+	if !capable {
+		t.Errorf("DirectMessages.IsCapable returned false, want true")
+	}
+}
+
+func TestDirectMessagesCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/new.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"text": "hi",
+			"uid":  "42",
+		})
+		fmt.Fprint(w, `{"id": 11, "text": "hi", "recipient_id": 42}`)
+	})
+
+	opt := &DirectMessageRequest{Text: String("hi"), UID: String("42")}
+	message, _, err := client.DirectMessages.Create(opt)
+
+	if err != nil {
+		t.Errorf("DirectMessages.Create returned error: %v", err)
+	}
+
+	want := &DirectMessage{ID: Int64(11), Text: String("hi"), RecipientID: Int64(42)}
+	if !reflect.DeepEqual(message, want) {
+		t.Errorf("DirectMessages.Create returned %+v, want %+v", message, want)
+	}
+}
+
+func TestDirectMessagesDestroy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"id": "11",
+		})
+		fmt.Fprint(w, `{"id": 11}`)
+	})
+
+	_, _, err := client.DirectMessages.Destroy(11)
+
+	if err != nil {
+		t.Errorf("DirectMessages.Destroy returned error: %v", err)
+	}
+}
+
+func TestDirectMessagesDestroyBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/direct_messages/destroy_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"ids": "11,12",
+		})
+		fmt.Fprint(w, `{"result": true}`)
+	})
+
+	_, err := client.DirectMessages.DestroyBatch([]int64{11, 12})
+
+	if err != nil {
+		t.Errorf("DirectMessages.DestroyBatch returned error: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package weibo
+
+import (
+	"html"
+	"regexp"
+)
+
+// EmotionsService handles communication with the emotion related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/emotions
+type EmotionsService struct {
+	client *Client
+}
+
+// Emotion represents a Weibo emoticon, which appears in status text as a
+// phrase such as "[哈哈]".
+type Emotion struct {
+	Phrase   *string `json:"phrase,omitempty"`
+	Type     *string `json:"type,omitempty"`
+	URL      *string `json:"url,omitempty"`
+	Icon     *string `json:"icon,omitempty"`
+	Value    *string `json:"value,omitempty"`
+	Category *string `json:"category,omitempty"`
+	Hot      *bool   `json:"hot,omitempty"`
+	Common   *bool   `json:"common,omitempty"`
+}
+
+// EmotionListOptions specifies the optional parameters to the
+// EmotionsService.List method.
+type EmotionListOptions struct {
+	// Type of the emotions, one of "face", "ani" or "cartoon".  Defaults
+	// to "face".
+	Type string `url:"type,omitempty"`
+
+	// Language of the phrases, "cnname" for simplified Chinese or "twname"
+	// for traditional Chinese.  Defaults to "cnname".
+	Language string `url:"language,omitempty"`
+}
+
+// List the official emotions.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/emotions
+func (s *EmotionsService) List(opt *EmotionListOptions) ([]Emotion, *Response, error) {
+	u, err := addOptions("emotions.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	emotions := new([]Emotion)
+	resp, err := s.client.Do(req, emotions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *emotions, resp, err
+}
+
+// emotionPhrase matches an emotion phrase such as "[哈哈]" in status text.
+var emotionPhrase = regexp.MustCompile(`\[[^\[\]]+\]`)
+
+// EmotionSet indexes emotions by their phrase.
+type EmotionSet struct {
+	emotions map[string]Emotion
+}
+
+// NewEmotionSet returns an EmotionSet of the given emotions, usually the
+// result of EmotionsService.List.
+func NewEmotionSet(emotions []Emotion) *EmotionSet {
+	set := &EmotionSet{emotions: make(map[string]Emotion, len(emotions))}
+	for _, e := range emotions {
+		if e.Phrase != nil {
+			set.emotions[*e.Phrase] = e
+		}
+	}
+	return set
+}
+
+// Lookup returns the emotion of phrase, which includes the surrounding
+// brackets, e.g. "[哈哈]".
+func (s *EmotionSet) Lookup(phrase string) (Emotion, bool) {
+	e, ok := s.emotions[phrase]
+	return e, ok
+}
+
+// URL returns the image URL of phrase.  It can be used as the Emoticon
+// field of a text.Linker.
+func (s *EmotionSet) URL(phrase string) (string, bool) {
+	e, ok := s.emotions[phrase]
+	if !ok || e.URL == nil {
+		return "", false
+	}
+	return *e.URL, true
+}
+
+// ReplaceHTML returns text as HTML with every known emotion phrase replaced
+// by an <img> tag.  The rest of text is HTML escaped.
+func (s *EmotionSet) ReplaceHTML(text string) string {
+	var buf []byte
+	last := 0
+	for _, loc := range emotionPhrase.FindAllStringIndex(text, -1) {
+		phrase := text[loc[0]:loc[1]]
+		e, ok := s.emotions[phrase]
+		if !ok || e.URL == nil {
+			continue
+		}
+
+		buf = append(buf, html.EscapeString(text[last:loc[0]])...)
+		buf = append(buf, emotionImg(phrase, *e.URL)...)
+		last = loc[1]
+	}
+	buf = append(buf, html.EscapeString(text[last:])...)
+
+	return string(buf)
+}
+
+// emotionImg returns the <img> tag of an emotion.
+func emotionImg(phrase, url string) string {
+	phrase, url = html.EscapeString(phrase), html.EscapeString(url)
+	return `<img src="` + url + `" alt="` + phrase + `" title="` + phrase + `">`
+}
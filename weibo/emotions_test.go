@@ -0,0 +1,75 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestEmotionsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/emotions.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"type":     "face",
+			"language": "cnname",
+		})
+		fmt.Fprint(w, `[{"phrase": "[哈哈]", "type": "face", "url": "http://img.t.sinajs.cn/haha.gif", "hot": false, "common": true, "category": "", "icon": "http://img.t.sinajs.cn/haha.gif"}]`)
+	})
+
+	opt := &EmotionListOptions{Type: "face", Language: "cnname"}
+	emotions, _, err := client.Emotions.List(opt)
+
+	if err != nil {
+		t.Errorf("Emotions.List returned error: %v", err)
+	}
+
+	want := []Emotion{{
+		Phrase:   String("[哈哈]"),
+		Type:     String("face"),
+		URL:      String("http://img.t.sinajs.cn/haha.gif"),
+		Icon:     String("http://img.t.sinajs.cn/haha.gif"),
+		Category: String(""),
+		Hot:      Bool(false),
+		Common:   Bool(true),
+	}}
+	if !reflect.DeepEqual(emotions, want) {
+		t.Errorf("Emotions.List returned %+v, want %+v", emotions, want)
+	}
+}
+
+func TestEmotionSet_Lookup(t *testing.T) {
+	set := NewEmotionSet([]Emotion{{Phrase: String("[哈哈]"), URL: String("haha.gif")}})
+
+	if e, ok := set.Lookup("[哈哈]"); !ok || *e.URL != "haha.gif" {
+		t.Errorf("Lookup([哈哈]) = %+v, %v, want haha.gif, true", e, ok)
+	}
+	if _, ok := set.Lookup("[嘻嘻]"); ok {
+		t.Errorf("Lookup([嘻嘻]) returned true, want false")
+	}
+}
+
+func TestEmotionSet_ReplaceHTML(t *testing.T) {
+	set := NewEmotionSet([]Emotion{
+		{Phrase: String("[哈哈]"), URL: String("http://img.t.sinajs.cn/haha.gif")},
+		{Phrase: String("[<b>]"), URL: String(`http://example.com/"b".gif`)},
+	})
+
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "hello"},
+		{"[哈哈]", `<img src="http://img.t.sinajs.cn/haha.gif" alt="[哈哈]" title="[哈哈]">`},
+		{"a<[哈哈]>[嘻嘻]", `a&lt;<img src="http://img.t.sinajs.cn/haha.gif" alt="[哈哈]" title="[哈哈]">&gt;[嘻嘻]`},
+		{"[<b>]", `<img src="http://example.com/&#34;b&#34;.gif" alt="[&lt;b&gt;]" title="[&lt;b&gt;]">`},
+	}
+
+	for _, tt := range tests {
+		if got := set.ReplaceHTML(tt.in); got != tt.want {
+			t.Errorf("ReplaceHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,316 @@
+package weibo
+
+import (
+	"fmt"
+)
+
+// GroupsService handles communication with the friendship group related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups
+type GroupsService struct {
+	client *Client
+}
+
+// Group represents a friendship group of a Weibo user.  Its ID is the list_id
+// used by Visible and StatusRequest.
+type Group struct {
+	ID              *int64   `json:"id,omitempty"`
+	IDStr           *string  `json:"idstr,omitempty"`
+	Name            *string  `json:"name,omitempty"`
+	Mode            *string  `json:"mode,omitempty"`
+	Visible         *int     `json:"visible,omitempty"`
+	LikeCount       *int     `json:"like_count,omitempty"`
+	MemberCount     *int     `json:"member_count,omitempty"`
+	Description     *string  `json:"description,omitempty"`
+	Tags            []string `json:"tags,omitempty"`
+	ProfileImageUrl *string  `json:"profile_image_url,omitempty"`
+	User            *User    `json:"user,omitempty"`
+	CreatedAt       *string  `json:"created_at,omitempty"`
+}
+
+// groupList represents a set of friendship groups.
+type groupList struct {
+	Lists       []Group `json:"lists,omitempty"`
+	TotalNumber *int    `json:"total_number,omitempty"`
+}
+
+// GroupMembers represents the members of a friendship group.
+type GroupMembers struct {
+	Users          []User `json:"users,omitempty"`
+	TotalNumber    *int   `json:"total_number,omitempty"`
+	PreviousCursor *int   `json:"previous_cursor,omitempty"`
+	NextCursor     *int   `json:"next_cursor,omitempty"`
+}
+
+// UserGroups represents the friendship groups a Weibo user is listed in.
+type UserGroups struct {
+	UID   *int64  `json:"uid,omitempty"`
+	Lists []Group `json:"lists,omitempty"`
+}
+
+// GroupTimelineOptions specifies the parameters to the
+// GroupsService.Timeline and GroupsService.TimelineIDs methods.
+type GroupTimelineOptions struct {
+	ListID  int64  `url:"list_id"`
+	SinceID string `url:"since_id,omitempty"`
+	MaxID   string `url:"max_id,omitempty"`
+	ListOptions
+}
+
+// GroupMembersOptions specifies the parameters to the
+// GroupsService.Members method.
+type GroupMembersOptions struct {
+	ListID int64 `url:"list_id"`
+	Count  int   `url:"count,omitempty"`
+	Cursor int   `url:"cursor,omitempty"`
+}
+
+// GroupRequest represents a request to create or update a friendship group.
+type GroupRequest struct {
+	Name        *string  `url:"name,omitempty"`
+	Description *string  `url:"description,omitempty"`
+	Tags        []string `url:"tags,comma,omitempty"`
+}
+
+// groupUpdateRequest represents a request to update a friendship group.
+type groupUpdateRequest struct {
+	ListID int64 `url:"list_id"`
+	GroupRequest
+}
+
+// groupMemberRequest represents a request to destroy a friendship group, or
+// to add a user to or remove a user from it.
+type groupMemberRequest struct {
+	ListID int64  `url:"list_id"`
+	UID    string `url:"uid,omitempty"`
+}
+
+// listedOptions specifies the parameters to the GroupsService.Listed method.
+type listedOptions struct {
+	UIDs []string `url:"uids,comma"`
+}
+
+// isMember represents the result of the GroupsService.IsMember method.
+type isMember struct {
+	Lists []Group `json:"lists,omitempty"`
+}
+
+// List the friendship groups of the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups
+func (s *GroupsService) List() ([]Group, *Response, error) {
+	u := "friendships/groups.json"
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	groups := new(groupList)
+	resp, err := s.client.Do(req, groups)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groups.Lists, resp, err
+}
+
+// Timeline of a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/timeline
+func (s *GroupsService) Timeline(opt *GroupTimelineOptions) (*Timeline, *Response, error) {
+	u, err := addOptions("friendships/groups/timeline.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeline := &Timeline{}
+	resp, err := s.client.Do(req, timeline)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timeline, resp, err
+}
+
+// TimelineIDs returns the status IDs of a friendship group's timeline.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/timeline/ids
+func (s *GroupsService) TimelineIDs(opt *GroupTimelineOptions) (*TimelineIDs, *Response, error) {
+	u, err := addOptions("friendships/groups/timeline/ids.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timelineIDs := &TimelineIDs{}
+	resp, err := s.client.Do(req, timelineIDs)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timelineIDs, resp, err
+}
+
+// Members lists the members of a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/members
+func (s *GroupsService) Members(opt *GroupMembersOptions) (*GroupMembers, *Response, error) {
+	u, err := addOptions("friendships/groups/members.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	members := new(GroupMembers)
+	resp, err := s.client.Do(req, members)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return members, resp, err
+}
+
+// IsMember checks whether a user is a member of a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/is_member
+func (s *GroupsService) IsMember(listID int64, uid string) (bool, *Response, error) {
+	u, err := addOptions("friendships/groups/is_member.json", &groupMemberRequest{ListID: listID, UID: uid})
+	if err != nil {
+		return false, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return false, nil, err
+	}
+
+	result := new(isMember)
+	resp, err := s.client.Do(req, result)
+	if err != nil {
+		return false, resp, err
+	}
+
+	for _, g := range result.Lists {
+		if g.ID != nil && *g.ID == listID {
+			return true, resp, err
+		}
+	}
+
+	return false, resp, err
+}
+
+// Listed returns the friendship groups the given users are listed in.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/listed
+func (s *GroupsService) Listed(uids []string) ([]UserGroups, *Response, error) {
+	u, err := addOptions("friendships/groups/listed.json", &listedOptions{UIDs: uids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userGroups := new([]UserGroups)
+	resp, err := s.client.Do(req, userGroups)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *userGroups, resp, err
+}
+
+// Show a single friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/show
+func (s *GroupsService) Show(listID int64) (*Group, *Response, error) {
+	u := fmt.Sprintf("friendships/groups/show.json?list_id=%v", listID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(Group)
+	resp, err := s.client.Do(req, group)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return group, resp, err
+}
+
+// Create a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/create
+func (s *GroupsService) Create(opt *GroupRequest) (*Group, *Response, error) {
+	return s.postGroup("friendships/groups/create.json", opt)
+}
+
+// Update a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/update
+func (s *GroupsService) Update(listID int64, opt *GroupRequest) (*Group, *Response, error) {
+	u := "friendships/groups/update.json"
+
+	body := &groupUpdateRequest{ListID: listID}
+	if opt != nil {
+		body.GroupRequest = *opt
+	}
+
+	return s.postGroup(u, body)
+}
+
+// Destroy a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/destroy
+func (s *GroupsService) Destroy(listID int64) (*Group, *Response, error) {
+	return s.postGroup("friendships/groups/destroy.json", &groupMemberRequest{ListID: listID})
+}
+
+// AddMember adds a user to a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/members/add
+func (s *GroupsService) AddMember(listID int64, uid string) (*Group, *Response, error) {
+	return s.postGroup("friendships/groups/members/add.json", &groupMemberRequest{ListID: listID, UID: uid})
+}
+
+// RemoveMember removes a user from a friendship group.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/friendships/groups/members/destroy
+func (s *GroupsService) RemoveMember(listID int64, uid string) (*Group, *Response, error) {
+	return s.postGroup("friendships/groups/members/destroy.json", &groupMemberRequest{ListID: listID, UID: uid})
+}
+
+// postGroup sends a POST request to u and decodes the returned group.
+func (s *GroupsService) postGroup(u string, body interface{}) (*Group, *Response, error) {
+	req, err := s.client.NewRequest("POST", u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	group := new(Group)
+	resp, err := s.client.Do(req, group)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return group, resp, err
+}
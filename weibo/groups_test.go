@@ -0,0 +1,289 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGroupsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"lists": [{"id": 1, "name": "family"}], "total_number": 1}`)
+	})
+
+	groups, _, err := client.Groups.List()
+
+	if err != nil {
+		t.Errorf("Groups.List returned error: %v", err)
+	}
+
+	want := []Group{{ID: Int64(1), Name: String("family")}}
+	if !reflect.DeepEqual(groups, want) {
+		t.Errorf("Groups.List returned %+v, want %+v", groups, want)
+	}
+}
+
+func TestGroupsTimeline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"list_id":  "1",
+			"since_id": "10",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 11, "text": "hello family"}], "total_number": 1}`)
+	})
+
+	opt := &GroupTimelineOptions{ListID: 1, SinceID: "10"}
+	timeline, _, err := client.Groups.Timeline(opt)
+
+	if err != nil {
+		t.Errorf("Groups.Timeline returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(11), Text: String("hello family")}}
+	if !reflect.DeepEqual(timeline.Statuses, want) {
+		t.Errorf("Groups.Timeline returned %+v, want %+v", timeline.Statuses, want)
+	}
+}
+
+func TestGroupsTimelineIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/timeline/ids.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"list_id": "1",
+		})
+		fmt.Fprint(w, `{"statuses": ["11", "12"], "total_number": 2}`)
+	})
+
+	timelineIDs, _, err := client.Groups.TimelineIDs(&GroupTimelineOptions{ListID: 1})
+
+	if err != nil {
+		t.Errorf("Groups.TimelineIDs returned error: %v", err)
+	}
+
+	want := []string{"11", "12"}
+	if !reflect.DeepEqual(timelineIDs.StatusesIDs, want) {
+		t.Errorf("Groups.TimelineIDs returned %+v, want %+v", timelineIDs.StatusesIDs, want)
+	}
+}
+
+func TestGroupsMembers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/members.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"list_id": "1",
+			"count":   "5",
+		})
+		fmt.Fprint(w, `{"users": [{"id": 42}], "next_cursor": 0, "previous_cursor": 0, "total_number": 1}`)
+	})
+
+	members, _, err := client.Groups.Members(&GroupMembersOptions{ListID: 1, Count: 5})
+
+	if err != nil {
+		t.Errorf("Groups.Members returned error: %v", err)
+	}
+
+	want := &GroupMembers{Users: []User{{ID: Int(42)}}, NextCursor: Int(0), PreviousCursor: Int(0), TotalNumber: Int(1)}
+	if !reflect.DeepEqual(members, want) {
+		t.Errorf("Groups.Members returned %+v, want %+v", members, want)
+	}
+}
+
+func TestGroupsIsMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/is_member.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"list_id": "1",
+			"uid":     "42",
+		})
+		fmt.Fprint(w, `{"lists": [{"id": 1}]}`)
+	})
+
+	member, _, err := client.Groups.IsMember(1, "42")
+
+	if err != nil {
+		t.Errorf("Groups.IsMember returned error: %v", err)
+	}
+
+	if !member {
+		t.Errorf("Groups.IsMember returned false, want true")
+	}
+}
+
+func TestGroupsListed(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/listed.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uids": "42,43",
+		})
+		fmt.Fprint(w, `[{"uid": 42, "lists": [{"id": 1}]}, {"uid": 43, "lists": []}]`)
+	})
+
+	listed, _, err := client.Groups.Listed([]string{"42", "43"})
+
+	if err != nil {
+		t.Errorf("Groups.Listed returned error: %v", err)
+	}
+
+	want := []UserGroups{
+		{UID: Int64(42), Lists: []Group{{ID: Int64(1)}}},
+		{UID: Int64(43), Lists: []Group{}},
+	}
+	if !reflect.DeepEqual(listed, want) {
+		t.Errorf("Groups.Listed returned %+v, want %+v", listed, want)
+	}
+}
+
+func TestGroupsShow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"list_id": "1",
+		})
+		fmt.Fprint(w, `{"id": 1, "name": "family"}`)
+	})
+
+	group, _, err := client.Groups.Show(1)
+
+	if err != nil {
+		t.Errorf("Groups.Show returned error: %v", err)
+	}
+
+	want := &Group{ID: Int64(1), Name: String("family")}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("Groups.Show returned %+v, want %+v", group, want)
+	}
+}
+
+func TestGroupsCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/create.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"name": "family",
+			"tags": "home,kin",
+		})
+		fmt.Fprint(w, `{"id": 1, "name": "family"}`)
+	})
+
+	opt := &GroupRequest{Name: String("family"), Tags: []string{"home", "kin"}}
+	group, _, err := client.Groups.Create(opt)
+
+	if err != nil {
+		t.Errorf("Groups.Create returned error: %v", err)
+	}
+
+	want := &Group{ID: Int64(1), Name: String("family")}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("Groups.Create returned %+v, want %+v", group, want)
+	}
+}
+
+func TestGroupsUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"list_id": "1",
+			"name":    "relatives",
+		})
+		fmt.Fprint(w, `{"id": 1, "name": "relatives"}`)
+	})
+
+	group, _, err := client.Groups.Update(1, &GroupRequest{Name: String("relatives")})
+
+	if err != nil {
+		t.Errorf("Groups.Update returned error: %v", err)
+	}
+
+	want := &Group{ID: Int64(1), Name: String("relatives")}
+	if !reflect.DeepEqual(group, want) {
+		t.Errorf("Groups.Update returned %+v, want %+v", group, want)
+	}
+}
+
+func TestGroupsDestroy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"list_id": "1",
+		})
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	_, _, err := client.Groups.Destroy(1)
+
+	if err != nil {
+		t.Errorf("Groups.Destroy returned error: %v", err)
+	}
+}
+
+func TestGroupsAddMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/members/add.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"list_id": "1",
+			"uid":     "42",
+		})
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	_, _, err := client.Groups.AddMember(1, "42")
+
+	if err != nil {
+		t.Errorf("Groups.AddMember returned error: %v", err)
+	}
+}
+
+func TestGroupsRemoveMember(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/friendships/groups/members/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"list_id": "1",
+			"uid":     "42",
+		})
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	_, _, err := client.Groups.RemoveMember(1, "42")
+
+	if err != nil {
+		t.Errorf("Groups.RemoveMember returned error: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package weibo
+
+import (
+	"fmt"
+)
+
+// LocationService handles communication with the location related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/location/geo
+type LocationService struct {
+	client *Client
+}
+
+// GeoLocation represents a geocoding result of the Weibo API.
+type GeoLocation struct {
+	Longitude    *string `json:"longitude,omitempty"`
+	Latitude     *string `json:"latitude,omitempty"`
+	City         *string `json:"city,omitempty"`
+	Province     *string `json:"province,omitempty"`
+	CityName     *string `json:"city_name,omitempty"`
+	ProvinceName *string `json:"province_name,omitempty"`
+	Address      *string `json:"address,omitempty"`
+	Pinyin       *string `json:"pinyin,omitempty"`
+	More         *string `json:"more,omitempty"`
+}
+
+// geoLocations represents a set of geocoding results.
+type geoLocations struct {
+	Geos []GeoLocation `json:"geos,omitempty"`
+}
+
+// addressToGeoOptions specifies the parameters to the
+// LocationService.AddressToGeo method.
+type addressToGeoOptions struct {
+	Address string `url:"address"`
+}
+
+// ipToGeoOptions specifies the parameters to the LocationService.IPToGeo
+// method.
+type ipToGeoOptions struct {
+	IP []string `url:"ip,comma"`
+}
+
+// GeoToAddress reverse geocodes a coordinate into an address.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/location/geo/geo_to_address
+func (s *LocationService) GeoToAddress(lat, long float64) ([]GeoLocation, *Response, error) {
+	u := fmt.Sprintf("location/geo/geo_to_address.json?coordinate=%v,%v", long, lat)
+	return s.geos(u)
+}
+
+// AddressToGeo geocodes an address into coordinates.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/location/geo/address_to_geo
+func (s *LocationService) AddressToGeo(address string) ([]GeoLocation, *Response, error) {
+	u, err := addOptions("location/geo/address_to_geo.json", &addressToGeoOptions{Address: address})
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.geos(u)
+}
+
+// IPToGeo geocodes IP addresses into coordinates.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/location/geo/ip_to_geo
+func (s *LocationService) IPToGeo(ips []string) ([]GeoLocation, *Response, error) {
+	u, err := addOptions("location/geo/ip_to_geo.json", &ipToGeoOptions{IP: ips})
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.geos(u)
+}
+
+// geos sends a GET request to u and decodes the returned geocoding results.
+func (s *LocationService) geos(u string) ([]GeoLocation, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	geos := new(geoLocations)
+	resp, err := s.client.Do(req, geos)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return geos.Geos, resp, err
+}
@@ -0,0 +1,79 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestLocationGeoToAddress(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/location/geo/geo_to_address.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"coordinate": "116.3,39.9",
+		})
+		fmt.Fprint(w, `{"geos": [{"longitude": "116.3", "latitude": "39.9", "city_name": "北京", "address": "北京市海淀区"}]}`)
+	})
+
+	geos, _, err := client.Location.GeoToAddress(39.9, 116.3)
+
+	if err != nil {
+		t.Errorf("Location.GeoToAddress returned error: %v", err)
+	}
+
+	want := []GeoLocation{{Longitude: String("116.3"), Latitude: String("39.9"), CityName: String("北京"), Address: String("北京市海淀区")}}
+	if !reflect.DeepEqual(geos, want) {
+		t.Errorf("Location.GeoToAddress returned %+v, want %+v", geos, want)
+	}
+}
+
+func TestLocationAddressToGeo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/location/geo/address_to_geo.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"address": "北京市海淀区",
+		})
+		fmt.Fprint(w, `{"geos": [{"longitude": "116.3", "latitude": "39.9"}]}`)
+	})
+
+	geos, _, err := client.Location.AddressToGeo("北京市海淀区")
+
+	if err != nil {
+		t.Errorf("Location.AddressToGeo returned error: %v", err)
+	}
+
+	want := []GeoLocation{{Longitude: String("116.3"), Latitude: String("39.9")}}
+	if !reflect.DeepEqual(geos, want) {
+		t.Errorf("Location.AddressToGeo returned %+v, want %+v", geos, want)
+	}
+}
+
+func TestLocationIPToGeo(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/location/geo/ip_to_geo.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ip": "202.108.22.5,8.8.8.8",
+		})
+		fmt.Fprint(w, `{"geos": [{"longitude": "116.3", "latitude": "39.9"}, {"longitude": "-122.0", "latitude": "37.4"}]}`)
+	})
+
+	geos, _, err := client.Location.IPToGeo([]string{"202.108.22.5", "8.8.8.8"})
+
+	if err != nil {
+		t.Errorf("Location.IPToGeo returned error: %v", err)
+	}
+
+	if len(geos) != 2 {
+		t.Errorf("Location.IPToGeo returned %d results, want 2", len(geos))
+	}
+}
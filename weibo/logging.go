@@ -0,0 +1,212 @@
+package weibo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// accessTokenParam is the request parameter which may carry the access
+// token instead of the Authorization header.
+const accessTokenParam = "access_token"
+
+// redactedParams are the request parameters which carry credentials.
+var redactedParams = []string{accessTokenParam, "client_secret", "refresh_token"}
+
+// redacted replaces credentials in logged requests.
+const redacted = "REDACTED"
+
+// LogOptions specifies the optional parameters to LoggingMiddleware.
+type LogOptions struct {
+	// Level of successful requests.  Defaults to slog.LevelInfo.
+	Level slog.Leveler
+
+	// ErrorLevel of failed requests, i.e. those returning an error or a
+	// status code outside the 200 range.  Defaults to slog.LevelError.
+	ErrorLevel slog.Leveler
+
+	// MaxBodyBytes enables debug mode if positive: the URL, headers and
+	// bodies of each request and its response are logged at
+	// slog.LevelDebug, with bodies truncated to MaxBodyBytes bytes.
+	MaxBodyBytes int
+}
+
+// LoggingMiddleware returns Middleware which logs the method, endpoint,
+// status code, Weibo error code, latency and attempts of each API request to
+// logger, or to slog.Default() if logger is nil.  The Authorization header
+// and token parameters are always redacted.
+//
+//	client.Use(weibo.LoggingMiddleware(logger, nil))
+func LoggingMiddleware(logger *slog.Logger, opt *LogOptions) Middleware {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	if opt == nil {
+		opt = &LogOptions{}
+	}
+	level, errorLevel := slog.LevelInfo, slog.LevelError
+	if opt.Level != nil {
+		level = opt.Level.Level()
+	}
+	if opt.ErrorLevel != nil {
+		errorLevel = opt.ErrorLevel.Level()
+	}
+
+	return func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			ctx := req.Context()
+			debug := opt.MaxBodyBytes > 0 && logger.Enabled(ctx, slog.LevelDebug)
+
+			var reqBody []byte
+			if debug {
+				reqBody = readRequestBody(req)
+			}
+
+			start := time.Now()
+			resp, err := next.Do(req)
+			latency := time.Since(start)
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("endpoint", Endpoint(req)),
+			}
+			lvl := level
+			var respBody []byte
+			if err != nil {
+				lvl = errorLevel
+				attrs = append(attrs, slog.String("error", redactError(err, req.URL)))
+			} else {
+				attrs = append(attrs, slog.Int("status", resp.StatusCode))
+				failed := resp.StatusCode < 200 || resp.StatusCode > 299
+				if failed || debug {
+					respBody = readResponseBody(resp)
+				}
+				if failed {
+					lvl = errorLevel
+					errorResponse := new(ErrorResponse)
+					if json.Unmarshal(respBody, errorResponse) == nil && errorResponse.ErrorCode != 0 {
+						attrs = append(attrs, slog.Int("error_code", errorResponse.ErrorCode))
+					}
+				}
+			}
+			attrs = append(attrs, slog.Duration("latency", latency))
+			if info, ok := RequestInfoFromContext(ctx); ok {
+				attrs = append(attrs, slog.Int("attempts", info.Attempts))
+			}
+			logger.LogAttrs(ctx, lvl, "weibo: request", attrs...)
+
+			if debug {
+				attrs := []slog.Attr{
+					slog.String("method", req.Method),
+					slog.String("url", redactURL(req.URL)),
+					slog.Any("request_header", redactHeader(req.Header)),
+					slog.String("request_body", truncate(redactForm(reqBody), opt.MaxBodyBytes)),
+				}
+				if resp != nil {
+					attrs = append(attrs,
+						slog.Any("response_header", resp.Header),
+						slog.String("response_body", truncate(respBody, opt.MaxBodyBytes)))
+				}
+				logger.LogAttrs(ctx, slog.LevelDebug, "weibo: request dump", attrs...)
+			}
+
+			return resp, err
+		})
+	}
+}
+
+// readRequestBody returns the body of req, leaving it unread for the next
+// Doer.
+func readRequestBody(req *http.Request) []byte {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err == nil {
+			defer body.Close()
+			data, _ := io.ReadAll(body)
+			return data
+		}
+	}
+	data, _ := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	return data
+}
+
+// readResponseBody returns the body of resp, leaving it unread for the
+// caller.
+func readResponseBody(resp *http.Response) []byte {
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return data
+}
+
+// redactHeader returns a copy of h with its Authorization header redacted.
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if v := h.Get("Authorization"); v != "" {
+		scheme, _, _ := strings.Cut(v, " ")
+		h.Set("Authorization", scheme+" "+redacted)
+	}
+	return h
+}
+
+// redactURL returns u with its token parameters redacted.
+func redactURL(u *url.URL) string {
+	r := *u
+	q := r.Query()
+	if redactValues(q) {
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// redactForm returns the form body with its token parameters redacted.
+// Bodies which are not forms are returned as they are.
+func redactForm(body []byte) []byte {
+	form, err := url.ParseQuery(string(body))
+	if err != nil || !redactValues(form) {
+		return body
+	}
+	return []byte(form.Encode())
+}
+
+// redactValues redacts the token parameters in v, and reports whether there
+// were any.
+func redactValues(v url.Values) bool {
+	found := false
+	for _, p := range redactedParams {
+		if _, ok := v[p]; ok {
+			v.Set(p, redacted)
+			found = true
+		}
+	}
+	return found
+}
+
+// redactError returns the message of err with u redacted, as errors
+// returned by http.Client include the request URL.
+func redactError(err error, u *url.URL) string {
+	return strings.ReplaceAll(err.Error(), u.String(), redactURL(u))
+}
+
+// truncate returns data as a string of at most n bytes, cut on a rune
+// boundary so that Chinese text is not garbled.
+func truncate(data []byte, n int) string {
+	if len(data) <= n {
+		return string(data)
+	}
+	for n > 0 && !utf8.RuneStart(data[n]) {
+		n--
+	}
+	return string(data[:n]) + "..."
+}
@@ -0,0 +1,162 @@
+package weibo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// logRecords decodes the JSON log records written to buf.
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		record := make(map[string]interface{})
+		if err := dec.Decode(&record); err != nil {
+			t.Fatalf("decoding log record: %v", err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statuses":[{"id":1}]}`)
+	})
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	client.Use(LoggingMiddleware(logger, nil))
+
+	timeline, _, err := client.Statuses.UserTimeline(nil)
+	if err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+	if len(timeline.Statuses) != 1 {
+		t.Errorf("Statuses.UserTimeline returned %d statuses, want 1", len(timeline.Statuses))
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 1 {
+		t.Fatalf("logged %d records, want 1", len(records))
+	}
+	r := records[0]
+	for k, want := range map[string]interface{}{
+		"level":    "INFO",
+		"method":   "GET",
+		"endpoint": "statuses/user_timeline",
+		"status":   float64(200),
+		"attempts": float64(1),
+	} {
+		if r[k] != want {
+			t.Errorf("logged %s = %v, want %v", k, r[k], want)
+		}
+	}
+	if _, ok := r["latency"]; !ok {
+		t.Error("latency not logged")
+	}
+	if _, ok := r["error_code"]; ok {
+		t.Error("error_code logged for a successful request")
+	}
+}
+
+func TestLoggingMiddleware_errorCode(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"repeat content!","error_code":20019,"request":"/2/statuses/update.json"}`)
+	})
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, nil))
+	client.Use(LoggingMiddleware(logger, &LogOptions{ErrorLevel: slog.LevelWarn}))
+
+	_, _, err := client.Statuses.Create(&StatusRequest{Status: String("hi")})
+	if err, ok := err.(*ErrorResponse); !ok || err.ErrorCode != 20019 {
+		t.Errorf("Statuses.Create returned error %v, want ErrorCode 20019", err)
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 1 {
+		t.Fatalf("logged %d records, want 1", len(records))
+	}
+	if r := records[0]; r["level"] != "WARN" || r["error_code"] != float64(20019) || r["status"] != float64(400) {
+		t.Errorf("logged %v, want level WARN, status 400 and error_code 20019", r)
+	}
+}
+
+func TestLoggingMiddleware_debug(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testPostFormValues(t, r, values{"status": "hello", "access_token": "secret"})
+		fmt.Fprint(w, `{"id":1,"text":"hello, this is a long response"}`)
+	})
+
+	buf := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client.Use(LoggingMiddleware(logger, &LogOptions{MaxBodyBytes: 10}))
+
+	req, _ := client.NewRequest("POST", "statuses/update.json?access_token=secret", &struct {
+		Status      string `url:"status"`
+		AccessToken string `url:"access_token"`
+	}{"hello", "secret"})
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "secret") || strings.Contains(out, "OAuth2 123") {
+		t.Errorf("log output contains credentials: %s", out)
+	}
+
+	records := logRecords(t, buf)
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(records))
+	}
+	dump := records[1]
+	if got, want := dump["request_body"], "access_tok..."; got != want {
+		t.Errorf("logged request_body = %v, want %v", got, want)
+	}
+	if got, want := dump["response_body"], `{"id":1,"t...`; got != want {
+		t.Errorf("logged response_body = %v, want %v", got, want)
+	}
+	header, _ := dump["request_header"].(map[string]interface{})
+	if got, want := fmt.Sprint(header["Authorization"]), "[OAuth2 REDACTED]"; got != want {
+		t.Errorf("logged Authorization = %v, want %v", got, want)
+	}
+	if url := fmt.Sprint(dump["url"]); !strings.Contains(url, "access_token=REDACTED") {
+		t.Errorf("logged url = %v, want access_token redacted", url)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		data string
+		n    int
+		want string
+	}{
+		{"hello", 10, "hello"},
+		{"hello", 3, "hel..."},
+		{"你好世界", 12, "你好世界"},
+		{"你好世界", 7, "你好..."},
+		{"你好世界", 5, "你..."},
+		{"你好世界", 2, "..."},
+	}
+	for _, tt := range tests {
+		if got := truncate([]byte(tt.data), tt.n); got != tt.want {
+			t.Errorf("truncate(%q, %d) = %q, want %q", tt.data, tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package weibo
+
+import (
+	"net/http"
+	"time"
+)
+
+// Observation describes a completed API request.
+type Observation struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Endpoint is the logical name of the API endpoint, e.g.
+	// "statuses/user_timeline".
+	Endpoint string
+
+	// StatusCode is the HTTP status code of the response, or 0 if no
+	// response was received.
+	StatusCode int
+
+	// ErrorCode is the Weibo error code of a failed request, or 0.
+	ErrorCode int
+
+	// Duration is the time from sending the request to receiving the
+	// response, including any retries made by middleware.
+	Duration time.Duration
+
+	// Attempts is the number of times the request was sent, see
+	// RequestInfo.Attempts.
+	Attempts int
+
+	// Err is the error sending the request, or the *ErrorResponse of a
+	// failed request.
+	Err error
+}
+
+// An Observer is notified of each API request sent by Client.Do, e.g. to
+// record metrics.  ObserveRequest is called from the goroutine calling Do,
+// so it must be safe for concurrent use if the Client is.
+type Observer interface {
+	ObserveRequest(o *Observation)
+}
+
+// ObserverFunc is an adapter to allow the use of ordinary functions as
+// Observers.
+type ObserverFunc func(o *Observation)
+
+// ObserveRequest calls f(o).
+func (f ObserverFunc) ObserveRequest(o *Observation) {
+	f(o)
+}
+
+// observe reports req to the Observer of c, if any, and ends its span.
+func (c *Client) observe(req *http.Request, span Span, start time.Time, resp *http.Response, err error) {
+	if c.Observer == nil && span == nil {
+		return
+	}
+
+	o := &Observation{
+		Method:   req.Method,
+		Endpoint: Endpoint(req),
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if info, ok := RequestInfoFromContext(req.Context()); ok {
+		o.Attempts = info.Attempts
+	}
+	if resp != nil {
+		o.StatusCode = resp.StatusCode
+	}
+	if errorResponse, ok := err.(*ErrorResponse); ok {
+		o.ErrorCode = errorResponse.ErrorCode
+	}
+	if c.Observer != nil {
+		c.Observer.ObserveRequest(o)
+	}
+	if span != nil {
+		span.End(o)
+	}
+}
@@ -0,0 +1,58 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClient_Observer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statuses":[]}`)
+	})
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"repeat content!","error_code":20019}`)
+	})
+
+	var observed []*Observation
+	client.Observer = ObserverFunc(func(o *Observation) {
+		observed = append(observed, o)
+	})
+
+	client.Statuses.UserTimeline(nil)
+	client.Statuses.Create(&StatusRequest{Status: String("hi")})
+
+	if len(observed) != 2 {
+		t.Fatalf("observed %d requests, want 2", len(observed))
+	}
+	if o := observed[0]; o.Method != "GET" || o.Endpoint != "statuses/user_timeline" || o.StatusCode != 200 || o.ErrorCode != 0 || o.Err != nil {
+		t.Errorf("observed %+v, want a successful statuses/user_timeline request", o)
+	}
+	if o := observed[1]; o.Method != "POST" || o.Endpoint != "statuses/update" || o.StatusCode != 400 || o.ErrorCode != 20019 || o.Err == nil {
+		t.Errorf("observed %+v, want a failed statuses/update request with ErrorCode 20019", o)
+	}
+	for _, o := range observed {
+		if o.Duration <= 0 {
+			t.Errorf("observed Duration = %v, want positive", o.Duration)
+		}
+	}
+}
+
+func TestClient_Observer_transportError(t *testing.T) {
+	setup()
+	teardown()
+
+	var observed *Observation
+	client.Observer = ObserverFunc(func(o *Observation) { observed = o })
+
+	if _, _, err := client.Remind.UnreadCount("1"); err == nil {
+		t.Fatal("Expected error to be returned")
+	}
+	if observed == nil || observed.StatusCode != 0 || observed.Err == nil {
+		t.Errorf("observed %+v, want a request without response", observed)
+	}
+}
@@ -0,0 +1,99 @@
+package weibo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// A Doer sends an HTTP request and returns an HTTP response.  *http.Client
+// implements Doer.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// DoerFunc is an adapter to allow the use of ordinary functions as Doers.
+type DoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer which sends API requests, e.g. to log requests,
+// record metrics or modify requests before they are sent.  Middleware sees
+// the raw http.Response, before it is checked for API errors.
+type Middleware func(next Doer) Doer
+
+// Use appends mw to the middleware chain of c.  Middleware registered first
+// is the outermost, i.e. it sees a request first and its response last.  Use
+// must not be called concurrently with requests made by c.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
+
+// doer returns the Doer used to send requests, wrapped by the middleware
+// chain of c.  Each request waits for the Limiter of c, if any, and is
+// counted in the Attempts of its RequestInfo when it reaches the HTTP client.
+func (c *Client) doer() Doer {
+	var d Doer = DoerFunc(func(req *http.Request) (*http.Response, error) {
+		if c.Limiter != nil {
+			if err := c.Limiter.allow(req, c.accessToken); err != nil {
+				return nil, err
+			}
+		}
+		if info, ok := RequestInfoFromContext(req.Context()); ok {
+			info.Attempts++
+		}
+		return c.client.Do(req)
+	})
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	return d
+}
+
+// RequestInfo holds metadata about an API request, available to middleware
+// through RequestInfoFromContext.
+type RequestInfo struct {
+	// Endpoint is the logical name of the API endpoint, e.g.
+	// "statuses/user_timeline".
+	Endpoint string
+
+	// Attempts is the number of times the request has been sent so far,
+	// which is more than one if middleware retried it.  Attempts of a
+	// request must be sequential.
+	Attempts int
+}
+
+type requestInfoKey struct{}
+
+// withRequestInfo returns a copy of ctx carrying info.
+func withRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the RequestInfo of an API request created by
+// Client.NewRequest, given the request's context.
+func RequestInfoFromContext(ctx context.Context) (*RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info, ok
+}
+
+// Endpoint returns the logical name of the API endpoint req is sent to, e.g.
+// "statuses/user_timeline", or the empty string if req was not created by
+// Client.NewRequest.
+func Endpoint(req *http.Request) string {
+	if info, ok := RequestInfoFromContext(req.Context()); ok {
+		return info.Endpoint
+	}
+	return ""
+}
+
+// endpointName returns the logical endpoint name of an API path such as
+// "2/statuses/user_timeline.json".
+func endpointName(path string) string {
+	path = strings.TrimPrefix(path, "/")
+	path = strings.TrimPrefix(path, weiboApiVersion+"/")
+	return strings.TrimSuffix(path, ".json")
+}
@@ -0,0 +1,150 @@
+package weibo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestEndpoint(t *testing.T) {
+	c := NewClient("123")
+
+	tests := map[string]string{
+		"statuses/user_timeline.json?uid=42": "statuses/user_timeline",
+		"/statuses/user_timeline/ids.json":   "statuses/user_timeline/ids",
+		"remind/unread_count.json":           "remind/unread_count",
+	}
+	for u, want := range tests {
+		req, _ := c.NewRequest("GET", u, nil)
+		if got := Endpoint(req); got != want {
+			t.Errorf("Endpoint(%q) = %q, want %q", u, got, want)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "https://example.com/", nil)
+	if got := Endpoint(req); got != "" {
+		t.Errorf("Endpoint of a plain request = %q, want empty", got)
+	}
+}
+
+func TestClient_Use(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Test"); got != "outer" {
+			t.Errorf("X-Test header = %q, want %q", got, "outer")
+		}
+		fmt.Fprint(w, `{"statuses":[]}`)
+	})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" "+Endpoint(req))
+				resp, err := next.Do(req)
+				calls = append(calls, fmt.Sprintf("%s %d", name, resp.StatusCode))
+				return resp, err
+			})
+		}
+	}
+	setHeader := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Test", "outer")
+			return next.Do(req)
+		})
+	}
+	client.Use(trace("a"), setHeader, trace("b"))
+
+	if _, _, err := client.Statuses.UserTimeline(nil); err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+
+	want := []string{
+		"a statuses/user_timeline",
+		"b statuses/user_timeline",
+		"b 200",
+		"a 200",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middleware calls = %v, want %v", calls, want)
+	}
+}
+
+func TestClient_Use_shortCircuit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request reached the server")
+	})
+
+	wantErr := fmt.Errorf("blocked")
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return nil, wantErr
+		})
+	})
+
+	if _, _, err := client.Remind.UnreadCount("1"); err == nil {
+		t.Error("Expected error to be returned")
+	}
+}
+
+func TestRequestInfo_Attempts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":1}`)
+	})
+
+	var attempts int
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				resp, err = next.Do(req)
+			}
+			info, _ := RequestInfoFromContext(req.Context())
+			attempts = info.Attempts
+			return resp, err
+		})
+	})
+
+	if _, _, err := client.Remind.UnreadCount("1"); err != nil {
+		t.Fatalf("Remind.UnreadCount returned error: %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("RequestInfo.Attempts = %d, want 2", attempts)
+	}
+}
+
+func TestDo_replacedContext(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":1}`)
+	})
+
+	var endpoint string
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			endpoint = Endpoint(req)
+			return next.Do(req)
+		})
+	})
+
+	req, _ := client.NewRequest("GET", "remind/unread_count.json?uid=1", nil)
+	if _, err := client.Do(req.WithContext(context.Background()), nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if want := "remind/unread_count"; endpoint != want {
+		t.Errorf("Endpoint = %q, want %q", endpoint, want)
+	}
+}
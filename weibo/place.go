@@ -0,0 +1,156 @@
+package weibo
+
+import (
+	"fmt"
+)
+
+// PlaceService handles communication with the place related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place
+type PlaceService struct {
+	client *Client
+}
+
+// POI represents a point of interest.
+type POI struct {
+	POIID          *string  `json:"poiid,omitempty"`
+	Title          *string  `json:"title,omitempty"`
+	Address        *string  `json:"address,omitempty"`
+	Lon            *float64 `json:"lon,omitempty"`
+	Lat            *float64 `json:"lat,omitempty"`
+	Category       *string  `json:"category,omitempty"`
+	CategoryName   *string  `json:"category_name,omitempty"`
+	City           *string  `json:"city,omitempty"`
+	Province       *string  `json:"province,omitempty"`
+	Country        *string  `json:"country,omitempty"`
+	URL            *string  `json:"url,omitempty"`
+	Phone          *string  `json:"phone,omitempty"`
+	Postcode       *string  `json:"postcode,omitempty"`
+	Icon           *string  `json:"icon,omitempty"`
+	CheckinNum     *int     `json:"checkin_num,omitempty"`
+	CheckinUserNum *int     `json:"checkin_user_num,omitempty"`
+	TipNum         *int     `json:"tip_num,omitempty"`
+	PhotoNum       *int     `json:"photo_num,omitempty"`
+	TodoNum        *int     `json:"todo_num,omitempty"`
+	Distance       *int     `json:"distance,omitempty"`
+}
+
+// NearbyUsers represents a set of users near a location.
+type NearbyUsers struct {
+	Users       []User `json:"users,omitempty"`
+	TotalNumber *int   `json:"total_number,omitempty"`
+}
+
+// NearbyOptions specifies the parameters to the PlaceService.NearbyTimeline
+// and PlaceService.NearbyUsers methods.
+type NearbyOptions struct {
+	Lat  float64 `url:"lat"`
+	Long float64 `url:"long"`
+
+	// Range of the search in meters.
+	Range int `url:"range,omitempty"`
+
+	// StartTime and EndTime limit the results by unix timestamp.
+	StartTime int64 `url:"starttime,omitempty"`
+	EndTime   int64 `url:"endtime,omitempty"`
+
+	// Sort by 0 for time, 1 for distance.
+	Sort int `url:"sort,omitempty"`
+	ListOptions
+}
+
+// POITimelineOptions specifies the parameters to the
+// PlaceService.POITimeline method.
+type POITimelineOptions struct {
+	POIID   string `url:"poiid"`
+	SinceID string `url:"since_id,omitempty"`
+	MaxID   string `url:"max_id,omitempty"`
+	ListOptions
+}
+
+// NearbyTimeline returns the statuses posted near a location.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place/nearby_timeline
+func (s *PlaceService) NearbyTimeline(opt *NearbyOptions) (*Timeline, *Response, error) {
+	return s.timeline("place/nearby_timeline.json", opt)
+}
+
+// UserTimeline returns the geo-tagged statuses of a user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place/user_timeline
+func (s *PlaceService) UserTimeline(opt *StatusListOptions) (*Timeline, *Response, error) {
+	return s.timeline("place/user_timeline.json", opt)
+}
+
+// POITimeline returns the statuses posted at a point of interest.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place/poi_timeline
+func (s *PlaceService) POITimeline(opt *POITimelineOptions) (*Timeline, *Response, error) {
+	return s.timeline("place/poi_timeline.json", opt)
+}
+
+// NearbyUsers returns the users near a location.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place/nearby/users
+func (s *PlaceService) NearbyUsers(opt *NearbyOptions) (*NearbyUsers, *Response, error) {
+	u, err := addOptions("place/nearby/users.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := new(NearbyUsers)
+	resp, err := s.client.Do(req, users)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return users, resp, err
+}
+
+// ShowPOI returns a single point of interest.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/place/pois/show
+func (s *PlaceService) ShowPOI(poiID string) (*POI, *Response, error) {
+	u := fmt.Sprintf("place/pois/show.json?poiid=%v", poiID)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	poi := new(POI)
+	resp, err := s.client.Do(req, poi)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return poi, resp, err
+}
+
+// timeline sends a GET request to u with opt and decodes the returned
+// timeline.
+func (s *PlaceService) timeline(u string, opt interface{}) (*Timeline, *Response, error) {
+	u, err := addOptions(u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeline := &Timeline{}
+	resp, err := s.client.Do(req, timeline)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timeline, resp, err
+}
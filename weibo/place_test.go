@@ -0,0 +1,153 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestPlaceNearbyTimeline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/place/nearby_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"lat":   "39.9",
+			"long":  "116.3",
+			"range": "500",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 1, "geo": {"type": "Point", "coordinates": [39.9, 116.3]}}], "total_number": 1}`)
+	})
+
+	opt := &NearbyOptions{Lat: 39.9, Long: 116.3, Range: 500}
+	timeline, _, err := client.Place.NearbyTimeline(opt)
+
+	if err != nil {
+		t.Errorf("Place.NearbyTimeline returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(1), Geo: &Geo{Type: String("Point"), Coordinates: []float64{39.9, 116.3}}}}
+	if !reflect.DeepEqual(timeline.Statuses, want) {
+		t.Errorf("Place.NearbyTimeline returned %+v, want %+v", timeline.Statuses, want)
+	}
+}
+
+func TestPlaceUserTimeline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/place/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uid": "42",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 1}], "total_number": 1}`)
+	})
+
+	timeline, _, err := client.Place.UserTimeline(&StatusListOptions{UID: "42"})
+
+	if err != nil {
+		t.Errorf("Place.UserTimeline returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(1)}}
+	if !reflect.DeepEqual(timeline.Statuses, want) {
+		t.Errorf("Place.UserTimeline returned %+v, want %+v", timeline.Statuses, want)
+	}
+}
+
+func TestPlacePOITimeline(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/place/poi_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"poiid": "B2094757D06FA3FD4A9C",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 1}], "total_number": 1}`)
+	})
+
+	timeline, _, err := client.Place.POITimeline(&POITimelineOptions{POIID: "B2094757D06FA3FD4A9C"})
+
+	if err != nil {
+		t.Errorf("Place.POITimeline returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(1)}}
+	if !reflect.DeepEqual(timeline.Statuses, want) {
+		t.Errorf("Place.POITimeline returned %+v, want %+v", timeline.Statuses, want)
+	}
+}
+
+func TestPlaceNearbyUsers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/place/nearby/users.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"lat":  "39.9",
+			"long": "116.3",
+		})
+		fmt.Fprint(w, `{"users": [{"id": 42}], "total_number": 1}`)
+	})
+
+	users, _, err := client.Place.NearbyUsers(&NearbyOptions{Lat: 39.9, Long: 116.3})
+
+	if err != nil {
+		t.Errorf("Place.NearbyUsers returned error: %v", err)
+	}
+
+	want := &NearbyUsers{Users: []User{{ID: Int(42)}}, TotalNumber: Int(1)}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Place.NearbyUsers returned %+v, want %+v", users, want)
+	}
+}
+
+func TestPlaceShowPOI(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/place/pois/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"poiid": "B2094757D06FA3FD4A9C",
+		})
+		fmt.Fprint(w, `{"poiid": "B2094757D06FA3FD4A9C", "title": "天安门", "lon": 116.39, "lat": 39.9}`)
+	})
+
+	poi, _, err := client.Place.ShowPOI("B2094757D06FA3FD4A9C")
+
+	if err != nil {
+		t.Errorf("Place.ShowPOI returned error: %v", err)
+	}
+
+	lon, lat := 116.39, 39.9
+	want := &POI{POIID: String("B2094757D06FA3FD4A9C"), Title: String("天安门"), Lon: &lon, Lat: &lat}
+	if !reflect.DeepEqual(poi, want) {
+		t.Errorf("Place.ShowPOI returned %+v, want %+v", poi, want)
+	}
+}
+
+func TestGeo_LatLong(t *testing.T) {
+	g := &Geo{Type: String("Point"), Coordinates: []float64{39.9, 116.3}}
+	if g.Lat() != 39.9 || g.Long() != 116.3 {
+		t.Errorf("Geo.Lat(), Geo.Long() = %v, %v, want 39.9, 116.3", g.Lat(), g.Long())
+	}
+
+	g = &Geo{}
+	if g.Lat() != 0 || g.Long() != 0 {
+		t.Errorf("Geo.Lat(), Geo.Long() = %v, %v, want 0, 0", g.Lat(), g.Long())
+	}
+	var status Status
+	if err := json.Unmarshal([]byte(`{"id": 1, "geo": null}`), &status); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if status.Geo.Lat() != 0 || status.Geo.Long() != 0 {
+		t.Errorf("Geo.Lat(), Geo.Long() of a null geo = %v, %v, want 0, 0", status.Geo.Lat(), status.Geo.Long())
+	}
+}
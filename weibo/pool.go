@@ -0,0 +1,230 @@
+package weibo
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrNoHealthyClient is returned by ClientPool.Do if all tokens of the pool
+// are quarantined.
+var ErrNoHealthyClient = errors.New("weibo: no healthy client in pool")
+
+// authErrorCodes are the Weibo error codes of requests whose access token
+// is invalid, expired or revoked.
+var authErrorCodes = map[int]bool{
+	21301: true, // auth failed
+	21314: true, // token used
+	21315: true, // token expired
+	21316: true, // token revoked
+	21317: true, // token rejected
+	21319: true, // authorization revoked
+	21327: true, // token expired
+	21332: true, // invalid access token
+}
+
+// rateLimitErrorCodes are the Weibo error codes of requests over the user or
+// endpoint rate limits, which reset every hour.
+var rateLimitErrorCodes = map[int]bool{
+	10023: true, // user requests out of rate limit
+	10024: true, // user requests for an endpoint out of rate limit
+}
+
+// ipRateLimitErrorCode is the Weibo error code of requests over the rate
+// limit of their IP address, which all tokens of a pool share.
+const ipRateLimitErrorCode = 10022
+
+// A ClientPool spreads requests across the Clients of many access tokens.
+// Each request uses the least-loaded healthy token.  Tokens failing
+// authentication are quarantined until they are added again, and tokens
+// over their rate limits until the limits reset.  As the tokens of a pool
+// share its IP address, all of them are quarantined if it exceeds the IP
+// rate limit.  A ClientPool is safe for concurrent use.
+type ClientPool struct {
+	newClient func(token string) *Client
+
+	// now is the clock quarantines end by.
+	now func() time.Time
+
+	mu      sync.Mutex
+	clients []*pooledClient
+}
+
+// pooledClient is a Client of a ClientPool along with its health.
+type pooledClient struct {
+	token  string
+	client *Client
+	stats  TokenStats
+}
+
+// TokenStats represents the health of a token in a ClientPool.
+type TokenStats struct {
+	Token string
+
+	// InFlight is the number of requests currently using the token.
+	InFlight int
+
+	// Requests and Errors count the completed requests using the token,
+	// and those which failed.
+	Requests int
+	Errors   int
+
+	// Revoked is true if the token failed authentication.
+	Revoked bool
+
+	// QuarantinedUntil is the time the rate limits of the token reset, if
+	// it exceeded them.
+	QuarantinedUntil time.Time
+
+	// LastError is the error of the last failed request.
+	LastError error
+}
+
+// healthy reports whether the token of s can be used at now.
+func (s *TokenStats) healthy(now time.Time) bool {
+	return !s.Revoked && !now.Before(s.QuarantinedUntil)
+}
+
+// NewClientPool returns a ClientPool of tokens.  newClient creates the Client
+// of each token, and defaults to NewClient.
+func NewClientPool(tokens []string, newClient func(token string) *Client) *ClientPool {
+	if newClient == nil {
+		newClient = NewClient
+	}
+	p := &ClientPool{newClient: newClient, now: time.Now}
+	for _, token := range tokens {
+		p.Add(token)
+	}
+	return p
+}
+
+// Add adds token to p, or makes it healthy again if it is already in p.
+func (p *ClientPool) Add(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, pc := range p.clients {
+		if pc.token == token {
+			pc.stats.Revoked = false
+			pc.stats.QuarantinedUntil = time.Time{}
+			return
+		}
+	}
+	p.clients = append(p.clients, &pooledClient{
+		token:  token,
+		client: p.newClient(token),
+		stats:  TokenStats{Token: token},
+	})
+}
+
+// Remove removes token from p.  Requests using it are not affected.
+func (p *ClientPool) Remove(token string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, pc := range p.clients {
+		if pc.token == token {
+			p.clients = append(p.clients[:i], p.clients[i+1:]...)
+			return
+		}
+	}
+}
+
+// Stats returns the health of each token in p.
+func (p *ClientPool) Stats() []TokenStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := make([]TokenStats, len(p.clients))
+	for i, pc := range p.clients {
+		stats[i] = pc.stats
+	}
+	return stats
+}
+
+// Do calls fn with the Client of the least-loaded healthy token in p, i.e.
+// the one with the fewest requests in flight, and then the fewest requests.
+// The error returned by fn is returned, after quarantining the token if it
+// reports an authentication or rate limit error.
+//
+//	err := pool.Do(func(c *weibo.Client) error {
+//		timeline, _, err = c.Statuses.UserTimeline(opt)
+//		return err
+//	})
+func (p *ClientPool) Do(fn func(c *Client) error) (err error) {
+	pc := p.acquire()
+	if pc == nil {
+		return ErrNoHealthyClient
+	}
+
+	// release pc even if fn panics, recording the panic as a failure
+	defer func() {
+		if r := recover(); r != nil {
+			p.release(pc, fmt.Errorf("weibo: panic: %v", r))
+			panic(r)
+		}
+		p.release(pc, err)
+	}()
+	return fn(pc.client)
+}
+
+// acquire returns the least-loaded healthy client of p, or nil if there is
+// none.
+func (p *ClientPool) acquire() *pooledClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var best *pooledClient
+	for _, pc := range p.clients {
+		if !pc.stats.healthy(now) {
+			continue
+		}
+		if best == nil || pc.stats.InFlight < best.stats.InFlight ||
+			pc.stats.InFlight == best.stats.InFlight && pc.stats.Requests < best.stats.Requests {
+			best = pc
+		}
+	}
+	if best != nil {
+		best.stats.InFlight++
+	}
+	return best
+}
+
+// release records the completion of a request using pc, which returned err.
+func (p *ClientPool) release(pc *pooledClient, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := &pc.stats
+	s.InFlight--
+	s.Requests++
+	if err == nil {
+		return
+	}
+	s.Errors++
+	s.LastError = err
+
+	now := p.now()
+	var rateLimitErr *RateLimitError
+	var errorResponse *ErrorResponse
+	switch {
+	case errors.As(err, &rateLimitErr):
+		s.QuarantinedUntil = now.Add(rateLimitErr.RetryAfter)
+	case errors.As(err, &errorResponse):
+		switch {
+		case authErrorCodes[errorResponse.ErrorCode] ||
+			errorResponse.Response != nil && errorResponse.Response.StatusCode == http.StatusUnauthorized:
+			s.Revoked = true
+		case rateLimitErrorCodes[errorResponse.ErrorCode]:
+			// Weibo rate limits reset on the hour.
+			s.QuarantinedUntil = now.Truncate(time.Hour).Add(time.Hour)
+		case errorResponse.ErrorCode == ipRateLimitErrorCode:
+			for _, other := range p.clients {
+				other.stats.QuarantinedUntil = now.Truncate(time.Hour).Add(time.Hour)
+			}
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestClientPool returns a ClientPool of tokens talking to the test
+// server, with a fixed clock.
+func newTestClientPool(tokens ...string) (*ClientPool, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2014, 1, 1, 10, 20, 0, 0, time.UTC)}
+	p := NewClientPool(tokens, func(token string) *Client {
+		c := NewClient(token)
+		c.BaseURL, _ = url.Parse(server.URL)
+		return c
+	})
+	p.now = clock.now
+	return p, clock
+}
+
+// tokenStats returns the stats of token in p.
+func tokenStats(p *ClientPool, token string) TokenStats {
+	for _, s := range p.Stats() {
+		if s.Token == token {
+			return s
+		}
+	}
+	return TokenStats{}
+}
+
+func TestClientPool_Do_leastLoaded(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":1}`)
+	})
+
+	p, _ := newTestClientPool("a", "b", "c")
+
+	// a request in flight on one token steers the others away from it
+	started, done := make(chan struct{}), make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		p.Do(func(c *Client) error {
+			close(started)
+			<-done
+			return nil
+		})
+	}()
+	<-started
+
+	used := make(map[string]int)
+	for i := 0; i < 4; i++ {
+		p.Do(func(c *Client) error {
+			used[c.accessToken]++
+			_, _, err := c.Remind.UnreadCount("1")
+			return err
+		})
+	}
+	close(done)
+	wg.Wait()
+
+	if used["a"] != 0 || used["b"] != 2 || used["c"] != 2 {
+		t.Errorf("requests per token = %v, want b and c twice each", used)
+	}
+	if s := tokenStats(p, "a"); s.InFlight != 0 || s.Requests != 1 {
+		t.Errorf("stats of a = %+v, want 1 completed request", s)
+	}
+}
+
+func TestClientPool_Do_quarantine(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "OAuth2 revoked":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_access_token","error_code":21332}`)
+		case "OAuth2 limited":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"error":"User requests out of rate limit!","error_code":10023}`)
+		default:
+			fmt.Fprint(w, `{"status":1}`)
+		}
+	})
+
+	p, clock := newTestClientPool("revoked", "limited", "ok")
+	unread := func(c *Client) error {
+		_, _, err := c.Remind.UnreadCount("1")
+		return err
+	}
+
+	for i := 0; i < 5; i++ {
+		p.Do(unread)
+	}
+
+	if s := tokenStats(p, "revoked"); !s.Revoked || s.Requests != 1 || s.Errors != 1 || s.LastError == nil {
+		t.Errorf("stats of revoked = %+v, want revoked after 1 failed request", s)
+	}
+	want := time.Date(2014, 1, 1, 11, 0, 0, 0, time.UTC)
+	if s := tokenStats(p, "limited"); s.Revoked || !s.QuarantinedUntil.Equal(want) || s.Requests != 1 {
+		t.Errorf("stats of limited = %+v, want quarantined until %v after 1 request", s, want)
+	}
+	if s := tokenStats(p, "ok"); s.Requests != 3 || s.Errors != 0 {
+		t.Errorf("stats of ok = %+v, want 3 successful requests", s)
+	}
+
+	// the rate limits reset on the hour
+	clock.t = want
+	p.Remove("ok")
+	if err := p.Do(unread); err == nil {
+		t.Error("request with the limited token returned no error")
+	}
+	if s := tokenStats(p, "limited"); s.Requests != 2 {
+		t.Errorf("limited token used for %d requests, want 2", s.Requests)
+	}
+
+	if err := p.Do(unread); err != ErrNoHealthyClient {
+		t.Errorf("Do returned %v, want %v", err, ErrNoHealthyClient)
+	}
+
+	p.Add("revoked")
+	if s := tokenStats(p, "revoked"); s.Revoked {
+		t.Errorf("stats of revoked = %+v after Add, want healthy", s)
+	}
+}
+
+func TestClientPool_Do_rateLimitError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, clock := newTestClientPool("a")
+	p.Do(func(c *Client) error {
+		return &RateLimitError{Endpoint: "statuses/update", Scope: "write", RetryAfter: time.Minute}
+	})
+
+	if s := tokenStats(p, "a"); !s.QuarantinedUntil.Equal(clock.t.Add(time.Minute)) {
+		t.Errorf("stats of a = %+v, want quarantined for a minute", s)
+	}
+}
+
+func TestClientPool_Do_ipRateLimit(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":"IP requests out of rate limit!","error_code":10022}`)
+	})
+
+	p, _ := newTestClientPool("a", "b", "c")
+	p.Do(func(c *Client) error {
+		_, _, err := c.Remind.UnreadCount("1")
+		return err
+	})
+
+	want := time.Date(2014, 1, 1, 11, 0, 0, 0, time.UTC)
+	for _, s := range p.Stats() {
+		if !s.QuarantinedUntil.Equal(want) {
+			t.Errorf("stats of %s = %+v, want quarantined until %v", s.Token, s, want)
+		}
+	}
+	if err := p.Do(func(c *Client) error { return nil }); err != ErrNoHealthyClient {
+		t.Errorf("Do returned %v, want %v", err, ErrNoHealthyClient)
+	}
+}
+
+func TestClientPool_Do_panic(t *testing.T) {
+	setup()
+	defer teardown()
+
+	p, _ := newTestClientPool("a")
+
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		p.Do(func(c *Client) error { panic("boom") })
+	}()
+
+	if recovered != "boom" {
+		t.Errorf("ClientPool.Do panicked with %v, want boom", recovered)
+	}
+	if s := tokenStats(p, "a"); s.InFlight != 0 || s.Errors != 1 || s.LastError == nil {
+		t.Errorf("stats of a = %+v after a panic, want a failed request and none in flight", s)
+	}
+}
@@ -0,0 +1,278 @@
+package weibo
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimitStatusEndpoint is exempt from rate limiting, as Weibo does not
+// count requests to it.
+const rateLimitStatusEndpoint = "account/rate_limit_status"
+
+// Budget is a number of requests allowed per period.  Requests are allowed
+// at a steady rate of Limit per Per, in bursts of up to Limit.  The zero
+// Budget is unlimited.
+type Budget struct {
+	Limit int
+	Per   time.Duration
+}
+
+func (b Budget) unlimited() bool {
+	return b.Limit <= 0 || b.Per <= 0
+}
+
+// RateLimits specifies the budgets enforced by a RateLimiter.
+type RateLimits struct {
+	// App is shared by all requests, i.e. by all access tokens of the app
+	// key using the RateLimiter.
+	App Budget
+
+	// Token applies to each access token.
+	Token Budget
+
+	// Read applies to the GET requests of each access token, and Write to
+	// the others, such as statuses/update.
+	Read  Budget
+	Write Budget
+
+	// Endpoints apply to each access token, by endpoint name such as
+	// "statuses/update".
+	Endpoints map[string]Budget
+}
+
+// RateLimitError is returned by Client.Do for requests over budget, if the
+// RateLimiter does not wait.
+type RateLimitError struct {
+	// Endpoint is the endpoint of the request.
+	Endpoint string
+
+	// Scope of the exceeded budget: "app", "token", "read", "write" or
+	// "endpoint".
+	Scope string
+
+	// RetryAfter is the time until the budget allows the request.
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("weibo: %s rate limit exceeded for %s, retry after %v",
+		e.Scope, e.Endpoint, e.RetryAfter)
+}
+
+// A RateLimiter enforces budgets on the requests sent by the Clients using
+// it, see Client.Limiter.  Share a RateLimiter between the Clients of an app
+// key to enforce the App budget.  A RateLimiter is safe for concurrent use.
+type RateLimiter struct {
+	// Wait, if true, makes requests over budget wait until they are
+	// allowed, or until their context is done.  Otherwise they fail fast
+	// with a *RateLimitError.
+	Wait bool
+
+	limits RateLimits
+
+	// now is the clock buckets refill by.
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[bucketKey]*bucket
+}
+
+// bucketKey identifies the bucket of a budget.  token and endpoint are
+// empty for scopes they do not apply to.
+type bucketKey struct {
+	scope    string
+	token    string
+	endpoint string
+}
+
+// bucket is a token bucket.
+type bucket struct {
+	capacity float64
+	rate     float64 // tokens per second
+	tokens   float64
+	last     time.Time
+}
+
+func newBucket(b Budget, now time.Time) *bucket {
+	return &bucket{
+		capacity: float64(b.Limit),
+		rate:     float64(b.Limit) / b.Per.Seconds(),
+		tokens:   float64(b.Limit),
+		last:     now,
+	}
+}
+
+// refill adds the tokens accrued since the last refill.
+func (b *bucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+	}
+	b.last = now
+}
+
+// wait returns the time until b has a token.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.tokens) / b.rate * float64(time.Second)))
+}
+
+// NewRateLimiter returns a RateLimiter enforcing limits.
+func NewRateLimiter(limits RateLimits) *RateLimiter {
+	return &RateLimiter{
+		limits:  limits,
+		now:     time.Now,
+		buckets: make(map[bucketKey]*bucket),
+	}
+}
+
+// bucket returns the bucket of key, creating it from budget if there is none
+// yet.  It returns nil if there is no bucket and budget is unlimited.  l.mu
+// must be held.
+func (l *RateLimiter) bucket(key bucketKey, budget Budget, now time.Time) *bucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		if budget.unlimited() {
+			return nil
+		}
+		b = newBucket(budget, now)
+		l.buckets[key] = b
+	}
+	b.refill(now)
+	return b
+}
+
+// take takes a token from each bucket applying to a request, and returns 0,
+// or else the time until the request is allowed and the scope of the
+// exceeded budget.
+func (l *RateLimiter) take(token, method, endpoint string) (time.Duration, string) {
+	class, classBudget := "read", l.limits.Read
+	if method != "GET" {
+		class, classBudget = "write", l.limits.Write
+	}
+	keys := []struct {
+		key    bucketKey
+		budget Budget
+	}{
+		{bucketKey{scope: "app"}, l.limits.App},
+		{bucketKey{scope: "token", token: token}, l.limits.Token},
+		{bucketKey{scope: class, token: token}, classBudget},
+		{bucketKey{scope: "endpoint", token: token, endpoint: endpoint}, l.limits.Endpoints[endpoint]},
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var buckets []*bucket
+	var wait time.Duration
+	var scope string
+	for _, k := range keys {
+		b := l.bucket(k.key, k.budget, now)
+		if b == nil {
+			continue
+		}
+		if w := b.wait(); w > wait {
+			wait, scope = w, k.key.scope
+		}
+		buckets = append(buckets, b)
+	}
+	if wait > 0 {
+		return wait, scope
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0, ""
+}
+
+// allow returns nil once the budgets allow req, sent with token.
+func (l *RateLimiter) allow(req *http.Request, token string) error {
+	endpoint := Endpoint(req)
+	if endpoint == rateLimitStatusEndpoint {
+		return nil
+	}
+
+	for {
+		wait, scope := l.take(token, req.Method, endpoint)
+		if wait == 0 {
+			return nil
+		}
+		if !l.Wait {
+			return &RateLimitError{Endpoint: endpoint, Scope: scope, RetryAfter: wait}
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Calibrate sets the token and endpoint budgets of token from status, as
+// returned by AccountService.RateLimitStatus, starting with the remaining
+// hits.  See also Client.CalibrateLimiter.
+func (l *RateLimiter) Calibrate(token string, status *RateLimitStatus) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	set := func(key bucketKey, limit, remaining *int, unit *string) {
+		if limit == nil || *limit <= 0 {
+			return
+		}
+		b := newBucket(Budget{Limit: *limit, Per: limitTimeUnit(unit)}, now)
+		if remaining != nil {
+			b.tokens = math.Min(b.capacity, float64(*remaining))
+		}
+		l.buckets[key] = b
+	}
+
+	set(bucketKey{scope: "token", token: token}, status.UserLimit, status.RemainingUserHits, status.LimitTimeUnit)
+	for _, api := range status.APIRateLimits {
+		if api.API == nil {
+			continue
+		}
+		endpoint := strings.TrimPrefix(*api.API, "/")
+		set(bucketKey{scope: "endpoint", token: token, endpoint: endpoint}, api.Limit, api.RemainingHits, api.LimitTimeUnit)
+	}
+}
+
+// limitTimeUnit returns the period of a Weibo limit_time_unit, which
+// defaults to an hour.
+func limitTimeUnit(unit *string) time.Duration {
+	if unit != nil {
+		switch *unit {
+		case "SECONDS":
+			return time.Second
+		case "MINUTES":
+			return time.Minute
+		case "DAYS":
+			return 24 * time.Hour
+		}
+	}
+	return time.Hour
+}
+
+// CalibrateLimiter fetches the rate limits of the authenticated user and
+// calibrates the Limiter of c with them.
+func (c *Client) CalibrateLimiter() (*Response, error) {
+	if c.Limiter == nil {
+		return nil, fmt.Errorf("weibo: client has no Limiter")
+	}
+
+	status, resp, err := c.Account.RateLimitStatus()
+	if err != nil {
+		return resp, err
+	}
+	c.Limiter.Calibrate(c.accessToken, status)
+	return resp, nil
+}
@@ -0,0 +1,182 @@
+package weibo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeClock is a manually advanced clock for RateLimiter.now.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestRateLimiter(limits RateLimits) (*RateLimiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2014, 1, 1, 0, 0, 0, 0, time.UTC)}
+	l := NewRateLimiter(limits)
+	l.now = clock.now
+	return l, clock
+}
+
+func rateLimitRequest(method, endpoint string) *http.Request {
+	req, _ := NewClient("").NewRequest(method, endpoint+".json", nil)
+	return req
+}
+
+func TestRateLimiter_token(t *testing.T) {
+	l, clock := newTestRateLimiter(RateLimits{Token: Budget{Limit: 2, Per: time.Minute}})
+	req := rateLimitRequest("GET", "statuses/user_timeline")
+
+	for i := 0; i < 2; i++ {
+		if err := l.allow(req, "a"); err != nil {
+			t.Fatalf("request %d returned error: %v", i, err)
+		}
+	}
+
+	err := l.allow(req, "a")
+	rateLimitErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("request over budget returned %v, want *RateLimitError", err)
+	}
+	if rateLimitErr.Scope != "token" || rateLimitErr.Endpoint != "statuses/user_timeline" || rateLimitErr.RetryAfter != 30*time.Second {
+		t.Errorf("RateLimitError = %+v, want token scope with RetryAfter 30s", rateLimitErr)
+	}
+
+	if err := l.allow(req, "b"); err != nil {
+		t.Errorf("request of another token returned error: %v", err)
+	}
+
+	clock.t = clock.t.Add(30 * time.Second)
+	if err := l.allow(req, "a"); err != nil {
+		t.Errorf("request after refill returned error: %v", err)
+	}
+}
+
+func TestRateLimiter_app(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimits{App: Budget{Limit: 1, Per: time.Hour}})
+	req := rateLimitRequest("GET", "statuses/user_timeline")
+
+	if err := l.allow(req, "a"); err != nil {
+		t.Fatalf("first request returned error: %v", err)
+	}
+	if err, ok := l.allow(req, "b").(*RateLimitError); !ok || err.Scope != "app" {
+		t.Errorf("request of another token returned %v, want app scope *RateLimitError", err)
+	}
+}
+
+func TestRateLimiter_class(t *testing.T) {
+	l, _ := newTestRateLimiter(RateLimits{
+		Write:     Budget{Limit: 1, Per: time.Hour},
+		Endpoints: map[string]Budget{"statuses/show": {Limit: 1, Per: time.Hour}},
+	})
+	update := rateLimitRequest("POST", "statuses/update")
+	timeline := rateLimitRequest("GET", "statuses/user_timeline")
+	show := rateLimitRequest("GET", "statuses/show")
+
+	if err := l.allow(update, "a"); err != nil {
+		t.Fatalf("first write returned error: %v", err)
+	}
+	if err, ok := l.allow(update, "a").(*RateLimitError); !ok || err.Scope != "write" {
+		t.Errorf("second write returned %v, want write scope *RateLimitError", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.allow(timeline, "a"); err != nil {
+			t.Errorf("read %d returned error: %v", i, err)
+		}
+	}
+	l.allow(show, "a")
+	if err, ok := l.allow(show, "a").(*RateLimitError); !ok || err.Scope != "endpoint" {
+		t.Errorf("second statuses/show returned %v, want endpoint scope *RateLimitError", err)
+	}
+}
+
+func TestRateLimiter_wait(t *testing.T) {
+	l := NewRateLimiter(RateLimits{Token: Budget{Limit: 1, Per: 50 * time.Millisecond}})
+	l.Wait = true
+	req := rateLimitRequest("GET", "statuses/user_timeline")
+
+	l.allow(req, "a")
+	start := time.Now()
+	if err := l.allow(req, "a"); err != nil {
+		t.Fatalf("waiting request returned error: %v", err)
+	}
+	if d := time.Since(start); d < 40*time.Millisecond {
+		t.Errorf("request waited %v, want about 50ms", d)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.allow(req.WithContext(ctx), "a"); err != context.Canceled {
+		t.Errorf("request with canceled context returned %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRateLimiter_Calibrate(t *testing.T) {
+	l, clock := newTestRateLimiter(RateLimits{})
+	l.Calibrate("a", &RateLimitStatus{
+		UserLimit:         Int(150),
+		RemainingUserHits: Int(1),
+		LimitTimeUnit:     String("HOURS"),
+		APIRateLimits: []APIRateLimit{{
+			API:           String("/statuses/update"),
+			Limit:         Int(30),
+			RemainingHits: Int(0),
+		}},
+	})
+
+	update := rateLimitRequest("POST", "statuses/update")
+	if err, ok := l.allow(update, "a").(*RateLimitError); !ok || err.Scope != "endpoint" || err.RetryAfter != 2*time.Minute {
+		t.Errorf("statuses/update returned %v, want endpoint scope *RateLimitError after 2m", err)
+	}
+
+	timeline := rateLimitRequest("GET", "statuses/user_timeline")
+	if err := l.allow(timeline, "a"); err != nil {
+		t.Errorf("first read returned error: %v", err)
+	}
+	if _, ok := l.allow(timeline, "a").(*RateLimitError); !ok {
+		t.Error("read over the remaining hits was allowed")
+	}
+
+	clock.t = clock.t.Add(24 * time.Second)
+	if err := l.allow(timeline, "a"); err != nil {
+		t.Errorf("read after refill returned error: %v", err)
+	}
+	if err := l.allow(timeline, "b"); err != nil {
+		t.Errorf("read of an uncalibrated token returned error: %v", err)
+	}
+}
+
+func TestClient_Limiter(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var requests int
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"status":1}`)
+	})
+	mux.HandleFunc("/2/account/rate_limit_status.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"user_limit":150,"remaining_user_hits":1,"limit_time_unit":"HOURS"}`)
+	})
+
+	client.Limiter = NewRateLimiter(RateLimits{})
+	if _, err := client.CalibrateLimiter(); err != nil {
+		t.Fatalf("CalibrateLimiter returned error: %v", err)
+	}
+
+	if _, _, err := client.Remind.UnreadCount("1"); err != nil {
+		t.Errorf("first request returned error: %v", err)
+	}
+	if _, _, err := client.Remind.UnreadCount("1"); err == nil {
+		t.Error("request over budget returned no error")
+	} else if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("request over budget returned %v, want *RateLimitError", err)
+	}
+	if requests != 1 {
+		t.Errorf("server received %d requests, want 1", requests)
+	}
+}
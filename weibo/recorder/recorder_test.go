@@ -0,0 +1,146 @@
+package recorder
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+// record records a user timeline request and a status update to a cassette,
+// returning its path and the URL of the since closed server.
+func record(t *testing.T) (string, string) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	mux.HandleFunc("/2/statuses/user_timeline.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statuses": [{"id": 1, "text": "hello"}], "total_number": 1}`)
+	})
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 2, "text": "`+r.PostFormValue("status")+`"}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "cassettes", "timeline.json")
+	r, err := New(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	client := newClient(server.URL, r)
+	if _, _, err := client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: "42"}); err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+
+	// a token passed as a query parameter must be scrubbed as well
+	req, _ := client.NewRequest("POST", "statuses/update.json?access_token=secret-token", &struct {
+		Status string `url:"status"`
+	}{"hi"})
+	if _, err := client.Do(req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if err := r.Stop(); err != nil {
+		t.Fatalf("Stop returned error: %v", err)
+	}
+	return path, server.URL
+}
+
+func newClient(baseURL string, r *Recorder) *weibo.Client {
+	client := weibo.NewClient("secret-token")
+	client.BaseURL, _ = url.Parse(baseURL + "/")
+	client.SetHTTPClient(r.Client())
+	return client
+}
+
+func TestRecorder_scrubsToken(t *testing.T) {
+	path, _ := record(t)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile returned error: %v", err)
+	}
+	if strings.Contains(string(data), "secret-token") {
+		t.Errorf("cassette contains the access token:\n%s", data)
+	}
+	if !strings.Contains(string(data), "user_timeline.json?uid=42") {
+		t.Errorf("cassette does not contain the request URL:\n%s", data)
+	}
+}
+
+func TestRecorder_replayStrict(t *testing.T) {
+	path, baseURL := record(t)
+
+	r, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	client := newClient(baseURL, r)
+
+	timeline, _, err := client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: "42"})
+	if err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+	if *timeline.Statuses[0].Text != "hello" {
+		t.Errorf("Statuses.UserTimeline returned %+v", timeline.Statuses)
+	}
+
+	// strict matching replays each interaction once, in order
+	_, _, err = client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: "42"})
+	if err == nil || !strings.Contains(err.Error(), ErrNoInteraction.Error()) {
+		t.Errorf("Statuses.UserTimeline replayed twice returned %v, want ErrNoInteraction", err)
+	}
+}
+
+func TestRecorder_replayLenient(t *testing.T) {
+	path, baseURL := record(t)
+
+	r, err := New(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	r.Matching = Lenient
+	client := newClient(baseURL, r)
+
+	for i := 0; i < 2; i++ {
+		_, _, err := client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: "42"})
+		if err != nil {
+			t.Errorf("Statuses.UserTimeline returned error: %v", err)
+		}
+	}
+
+	// the query must match, except for the scrubbed access token
+	_, _, err = client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: "43"})
+	if err == nil || !strings.Contains(err.Error(), ErrNoInteraction.Error()) {
+		t.Errorf("Statuses.UserTimeline of another user returned %v, want ErrNoInteraction", err)
+	}
+	req, _ := client.NewRequest("GET", "statuses/user_timeline.json?uid=42&access_token=other-token", nil)
+	if _, err := client.Do(req, nil); err != nil {
+		t.Errorf("Do with an access_token parameter returned error: %v", err)
+	}
+
+	status, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("other")})
+	if err != nil {
+		t.Fatalf("Statuses.Create returned error: %v", err)
+	}
+	if *status.Text != "hi" {
+		t.Errorf("Statuses.Create returned %+v, want the recorded status", status)
+	}
+
+	_, err = client.Remind.SetCount("dm")
+	if err == nil {
+		t.Errorf("Remind.SetCount returned no error, want ErrNoInteraction")
+	}
+}
+
+func TestNew_missingCassette(t *testing.T) {
+	if _, err := New(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Errorf("New returned no error for a missing cassette")
+	}
+}
@@ -0,0 +1,69 @@
+package weibo
+
+import (
+	"fmt"
+)
+
+// RemindService handles communication with the remind related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/remind/unread_count
+type RemindService struct {
+	client *Client
+}
+
+// UnreadCount represents the unread message counters of a Weibo user.
+type UnreadCount struct {
+	Status        *int `json:"status,omitempty"`
+	Follower      *int `json:"follower,omitempty"`
+	Cmt           *int `json:"cmt,omitempty"`
+	DM            *int `json:"dm,omitempty"`
+	MentionStatus *int `json:"mention_status,omitempty"`
+	MentionCmt    *int `json:"mention_cmt,omitempty"`
+	Group         *int `json:"group,omitempty"`
+	Notice        *int `json:"notice,omitempty"`
+	Invite        *int `json:"invite,omitempty"`
+	Badge         *int `json:"badge,omitempty"`
+	Photo         *int `json:"photo,omitempty"`
+}
+
+// setCountRequest represents a request to reset an unread counter.
+type setCountRequest struct {
+	Type string `url:"type"`
+}
+
+// UnreadCount fetches the unread message counters of a user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/remind/unread_count
+func (s *RemindService) UnreadCount(uid string) (*UnreadCount, *Response, error) {
+	u := fmt.Sprintf("remind/unread_count.json?uid=%v", uid)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	count := new(UnreadCount)
+	resp, err := s.client.Do(req, count)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return count, resp, err
+}
+
+// SetCount resets the unread counter of the given type for the authenticated
+// user.  countType is one of "follower", "cmt", "dm", "mention_status",
+// "mention_cmt", "group", "notice", "invite", "badge" or "photo".
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/remind/set_count
+func (s *RemindService) SetCount(countType string) (*Response, error) {
+	u := "remind/set_count.json"
+
+	req, err := s.client.NewRequest("POST", u, &setCountRequest{Type: countType})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
@@ -0,0 +1,65 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestRemindUnreadCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	uid := "42"
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uid": uid,
+		})
+		fmt.Fprint(w, `{"status": 1, "follower": 2, "cmt": 3, "dm": 4, "mention_status": 5, "mention_cmt": 6, "group": 0, "notice": 0, "invite": 0, "badge": 0, "photo": 0}`)
+	})
+
+	count, _, err := client.Remind.UnreadCount(uid)
+
+	if err != nil {
+		t.Errorf("Remind.UnreadCount returned error: %v", err)
+	}
+
+	want := &UnreadCount{
+		Status:        Int(1),
+		Follower:      Int(2),
+		Cmt:           Int(3),
+		DM:            Int(4),
+		MentionStatus: Int(5),
+		MentionCmt:    Int(6),
+		Group:         Int(0),
+		Notice:        Int(0),
+		Invite:        Int(0),
+		Badge:         Int(0),
+		Photo:         Int(0),
+	}
+	if !reflect.DeepEqual(count, want) {
+		t.Errorf("Remind.UnreadCount returned %+v, want %+v", count, want)
+	}
+}
+
+func TestRemindSetCount(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/set_count.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"type": "mention_status",
+		})
+		fmt.Fprint(w, `{"result": true}`)
+	})
+
+	_, err := client.Remind.SetCount("mention_status")
+
+	if err != nil {
+		t.Errorf("Remind.SetCount returned error: %v", err)
+	}
+}
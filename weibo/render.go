@@ -0,0 +1,39 @@
+package weibo
+
+import (
+	"strings"
+
+	"github.com/larrylv/go-weibo/weibo/text"
+)
+
+// HTML renders the full text of s as HTML, linking its entities with l, which
+// may be nil to use text.DefaultLinker.  The original status of a repost is
+// rendered in a <blockquote>.
+func (s *Status) HTML(l *text.Linker) string {
+	html := text.HTML(s.FullText(), l)
+	if rt := s.RetweetedStatus; rt != nil {
+		html += "<blockquote>" + text.HTML(rt.quoted(), l) + "</blockquote>"
+	}
+	return html
+}
+
+// Markdown renders the full text of s as Markdown, linking its entities with l,
+// which may be nil to use text.DefaultLinker.  The original status of a
+// repost is rendered as a block quote.
+func (s *Status) Markdown(l *text.Linker) string {
+	md := text.Markdown(s.FullText(), l)
+	if rt := s.RetweetedStatus; rt != nil {
+		quoted := text.Markdown(rt.quoted(), l)
+		md += "\n\n> " + strings.ReplaceAll(quoted, "\n", "\n> ")
+	}
+	return md
+}
+
+// quoted returns the full text of s prefixed with a mention of its author,
+// the way Weibo displays the original status of a repost.
+func (s *Status) quoted() string {
+	if s.User == nil || s.User.ScreeName == nil {
+		return s.FullText()
+	}
+	return "@" + *s.User.ScreeName + ": " + s.FullText()
+}
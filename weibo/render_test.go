@@ -0,0 +1,81 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo/text"
+)
+
+func TestStatus_HTML(t *testing.T) {
+	s := &Status{
+		Text: String("转发 [哈哈] <3"),
+		RetweetedStatus: &Status{
+			Text: String("#golang# http://t.cn/a"),
+			User: &User{ScreeName: String("larrylv")},
+		},
+	}
+	set := NewEmotionSet([]Emotion{{Phrase: String("[哈哈]"), URL: String("http://img.t.sinajs.cn/haha.gif")}})
+
+	want := `转发 <img src="http://img.t.sinajs.cn/haha.gif" alt="[哈哈]" title="[哈哈]"> &lt;3` +
+		`<blockquote><a href="https://weibo.com/n/larrylv">@larrylv</a>: ` +
+		`<a href="https://s.weibo.com/weibo?q=%23golang%23">#golang#</a> ` +
+		`<a href="http://t.cn/a">http://t.cn/a</a></blockquote>`
+	if got := s.HTML(&text.Linker{Emoticon: set.URL}); got != want {
+		t.Errorf("Status.HTML returned %q, want %q", got, want)
+	}
+}
+
+func TestStatus_Markdown(t *testing.T) {
+	s := &Status{
+		Text: String("转发"),
+		RetweetedStatus: &Status{
+			Text: String("line 1\nline 2"),
+		},
+	}
+
+	want := "转发\n\n> line 1\\\n> line 2"
+	if got := s.Markdown(nil); got != want {
+		t.Errorf("Status.Markdown returned %q, want %q", got, want)
+	}
+}
+
+func TestStatus_HTML_longText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"statuses": [`+
+			`{"id": 1, "isLongText": true, "longText": {"longTextContent": "full text @larrylv"}},`+
+			`{"id": 2, "isLongText": true, "longText": {"longTextContent": "quoted #golang#"}}]}`)
+	})
+
+	statuses := []Status{{
+		ID:         Int64(1),
+		Text:       String("full…全文"),
+		IsLongText: Bool(true),
+		RetweetedStatus: &Status{
+			ID:         Int64(2),
+			Text:       String("quoted…全文"),
+			IsLongText: Bool(true),
+		},
+	}}
+	if _, err := client.Statuses.ExpandLongText(statuses); err != nil {
+		t.Fatalf("Statuses.ExpandLongText returned error: %v", err)
+	}
+	s := &statuses[0]
+
+	want := `full text <a href="https://weibo.com/n/larrylv">@larrylv</a>` +
+		`<blockquote>quoted <a href="https://s.weibo.com/weibo?q=%23golang%23">#golang#</a></blockquote>`
+	if got := s.HTML(nil); got != want {
+		t.Errorf("Status.HTML returned %q, want %q", got, want)
+	}
+	if got := s.Markdown(nil); !strings.HasPrefix(got, "full text ") || !strings.Contains(got, "> quoted ") {
+		t.Errorf("Status.Markdown returned %q, want the full texts", got)
+	}
+	if entities := s.Entities(); len(entities) != 1 || entities[0].Value != "larrylv" {
+		t.Errorf("Status.Entities returned %+v, want the mention in the full text", entities)
+	}
+}
@@ -0,0 +1,65 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Source represents the application a Weibo status was posted from.  The
+// Weibo API encodes it as an HTML anchor, such as
+// `<a href="http://app.weibo.com/t/feed/..." rel="nofollow">iPhone客户端</a>`.
+type Source struct {
+	Name string // name of the application, e.g. "iPhone客户端"
+	URL  string // link to the application, if any
+	Raw  string // the original HTML
+}
+
+// sourceAnchor matches the HTML anchor of a status source.
+var sourceAnchor = regexp.MustCompile(`(?is)^\s*<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>\s*$`)
+
+// htmlTag matches an HTML tag.
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// ParseSource parses the HTML of a status source.  HTML that is not an
+// anchor is taken as the name of the application.
+func ParseSource(raw string) *Source {
+	s := &Source{Raw: raw}
+	if m := sourceAnchor.FindStringSubmatch(raw); m != nil {
+		s.URL = html.UnescapeString(m[1])
+		raw = m[2]
+	}
+	s.Name = strings.TrimSpace(html.UnescapeString(htmlTag.ReplaceAllString(raw, "")))
+	return s
+}
+
+func (s *Source) String() string {
+	return s.Name
+}
+
+// UnmarshalJSON decodes the HTML string of a status source.
+func (s *Source) UnmarshalJSON(data []byte) error {
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*s = *ParseSource(raw)
+	return nil
+}
+
+// MarshalJSON encodes s back to its original HTML string.  A Source not
+// parsed from HTML, i.e. with an empty Raw, is encoded as an anchor linking
+// Name to URL, or as Name alone if it has no URL.
+func (s Source) MarshalJSON() ([]byte, error) {
+	raw := s.Raw
+	if raw == "" {
+		raw = html.EscapeString(s.Name)
+		if s.URL != "" {
+			raw = fmt.Sprintf(`<a href="%s" rel="nofollow">%s</a>`, html.EscapeString(s.URL), raw)
+		}
+	}
+	return json.Marshal(raw)
+}
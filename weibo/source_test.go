@@ -0,0 +1,95 @@
+package weibo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseSource(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Source
+	}{
+		{
+			`<a href="http://app.weibo.com/t/feed/3o33sO" rel="nofollow">iPhone客户端</a>`,
+			Source{Name: "iPhone客户端", URL: "http://app.weibo.com/t/feed/3o33sO"},
+		},
+		{
+			`<a rel="nofollow" href='http://weibo.com/?a=1&amp;b=2'>Tom &amp; <b>Jerry</b></a>`,
+			Source{Name: "Tom & Jerry", URL: "http://weibo.com/?a=1&b=2"},
+		},
+		{`微博 weibo.com`, Source{Name: "微博 weibo.com"}},
+		{``, Source{}},
+	}
+
+	for _, tt := range tests {
+		tt.want.Raw = tt.raw
+		if got := ParseSource(tt.raw); !reflect.DeepEqual(*got, tt.want) {
+			t.Errorf("ParseSource(%q) = %+v, want %+v", tt.raw, *got, tt.want)
+		}
+	}
+}
+
+func TestSource_JSON(t *testing.T) {
+	raw := `{"id":1,"source":"<a href=\"http://app.weibo.com/t/feed/3o33sO\" rel=\"nofollow\">iPhone客户端</a>"}`
+
+	var status Status
+	if err := json.Unmarshal([]byte(raw), &status); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	want := &Source{
+		Name: "iPhone客户端",
+		URL:  "http://app.weibo.com/t/feed/3o33sO",
+		Raw:  `<a href="http://app.weibo.com/t/feed/3o33sO" rel="nofollow">iPhone客户端</a>`,
+	}
+	if !reflect.DeepEqual(status.Source, want) {
+		t.Errorf("Status.Source = %+v, want %+v", status.Source, want)
+	}
+
+	data, err := json.Marshal(status.Source)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+
+	var source string
+	json.Unmarshal(data, &source)
+	if source != want.Raw {
+		t.Errorf("json.Marshal encoded %q, want %q", source, want.Raw)
+	}
+}
+
+func TestSource_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		source Source
+		want   string
+	}{
+		{Source{Name: "iPhone客户端", Raw: "<b>raw</b>"}, "<b>raw</b>"},
+		{
+			Source{Name: "Tom & Jerry", URL: "http://weibo.com/?a=1&b=2"},
+			`<a href="http://weibo.com/?a=1&amp;b=2" rel="nofollow">Tom &amp; Jerry</a>`,
+		},
+		{Source{Name: "微博 weibo.com"}, "微博 weibo.com"},
+		{Source{}, ""},
+	}
+
+	for _, tt := range tests {
+		// a value, not a pointer, to check the receiver
+		data, err := json.Marshal(tt.source)
+		if err != nil {
+			t.Fatalf("json.Marshal returned error: %v", err)
+		}
+
+		var got string
+		json.Unmarshal(data, &got)
+		if got != tt.want {
+			t.Errorf("json.Marshal(%+v) encoded %q, want %q", tt.source, got, tt.want)
+		}
+		if tt.source.Raw == "" {
+			if parsed := ParseSource(got); parsed.Name != tt.source.Name || parsed.URL != tt.source.URL {
+				t.Errorf("ParseSource(%q) = %+v, want %+v", got, *parsed, tt.source)
+			}
+		}
+	}
+}
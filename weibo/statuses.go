@@ -1,7 +1,11 @@
 package weibo
 
 import (
+	"context"
+	"encoding/json"
 	_ "fmt"
+
+	"github.com/larrylv/go-weibo/weibo/text"
 )
 
 // StatusesService handles communication with the Status related
@@ -14,25 +18,88 @@ type StatusesService struct {
 
 // Status represents a Weibo's status.
 type Status struct {
-	CreatedAt      *string  `json:"created_at,omitempty"`
-	ID             *int64   `json:"id,omitempty"`
-	MID            *string  `json:"mid,omitempty"`
-	IDStr          *string  `json:"idstr,omitempty"`
-	Text           *string  `json:"text,omitempty"`
-	Source         *string  `json:"source,omitempty"`
-	Favorited      *bool    `json:"favorited,omitempty"`
-	Truncated      *bool    `json:"truncated,omitempty"`
-	User           *User    `json:"user,omitempty"`
-	RepostsCount   *int     `json:"reposts_count,omitempty"`
-	CommentsCount  *int     `json:"comments_count,omitempty"`
-	AttitudesCount *int     `json:"attitudes_count,omitemtpy"`
-	Visible        *Visible `json:"visible,omitempty"`
+	CreatedAt      *string     `json:"created_at,omitempty"`
+	ID             *int64      `json:"id,omitempty"`
+	MID            *string     `json:"mid,omitempty"`
+	IDStr          *string     `json:"idstr,omitempty"`
+	Text           *string     `json:"text,omitempty"`
+	Source         *Source     `json:"source,omitempty"`
+	Favorited      *bool       `json:"favorited,omitempty"`
+	Truncated      *bool       `json:"truncated,omitempty"`
+	User           *User       `json:"user,omitempty"`
+	RepostsCount   *int        `json:"reposts_count,omitempty"`
+	CommentsCount  *int        `json:"comments_count,omitempty"`
+	AttitudesCount *int        `json:"attitudes_count,omitemtpy"`
+	Visible        *Visible    `json:"visible,omitempty"`
+	Geo            *Geo        `json:"geo,omitempty"`
+	Annotations    Annotations `json:"annotations,omitempty"`
+
+	// IsLongText reports whether Text is truncated from a long status,
+	// whose full text is in LongText once fetched, see
+	// StatusesService.ShowLongText and StatusesService.ExpandLongText.
+	IsLongText *bool     `json:"isLongText,omitempty"`
+	LongText   *LongText `json:"longText,omitempty"`
+
+	// RetweetedStatus is the original status of a repost.
+	RetweetedStatus *Status `json:"retweeted_status,omitempty"`
+}
+
+// LongText represents the full text of a long Weibo status.
+type LongText struct {
+	Content *string `json:"longTextContent,omitempty"`
+}
+
+// FullText returns the full text of s if it has been fetched, and its text
+// otherwise.
+func (s *Status) FullText() string {
+	if s.LongText != nil && s.LongText.Content != nil {
+		return *s.LongText.Content
+	}
+	if s.Text == nil {
+		return ""
+	}
+	return *s.Text
+}
+
+// Geo represents the location a Weibo status was posted from.
+type Geo struct {
+	Type *string `json:"type,omitempty"`
+
+	// Coordinates of a "Point", in [latitude, longitude] order.
+	Coordinates []float64 `json:"coordinates,omitempty"`
+}
+
+// Lat returns the latitude of g, or 0 if g is nil or has no coordinates, as
+// for statuses with a null geo.
+func (g *Geo) Lat() float64 {
+	if g == nil || len(g.Coordinates) < 2 {
+		return 0
+	}
+	return g.Coordinates[0]
+}
+
+// Long returns the longitude of g, or 0 if g is nil or has no coordinates, as
+// for statuses with a null geo.
+func (g *Geo) Long() float64 {
+	if g == nil || len(g.Coordinates) < 2 {
+		return 0
+	}
+	return g.Coordinates[1]
+}
+
+// Entities returns the mentions, topics, URLs and emoticons in the full text
+// of s.  See the text package for details.
+func (s *Status) Entities() []text.Entity {
+	if s.Text == nil && s.LongText == nil {
+		return nil
+	}
+	return text.Extract(s.FullText())
 }
 
 // Visible represents visible object of a Weibo status.
 type Visible struct {
-	VType  *int `json:"type,omitempty"`
-	ListID *int `json:"list_id,omitempty"`
+	VType  *Visibility `json:"type,omitempty"`
+	ListID *int64      `json:"list_id,omitempty"`
 }
 
 // Timeline represents Weibo statuses set.
@@ -61,15 +128,36 @@ type StatusListOptions struct {
 	ListOptions
 }
 
+// maxShowBatchIDs is the maximum number of IDs accepted by the
+// statuses/show_batch endpoint.
+const maxShowBatchIDs = 50
+
+// showOptions specifies the parameters to the StatusesService.ShowLongText
+// and StatusesService.ShowBatch methods.
+type showOptions struct {
+	ID            int64   `url:"id,omitempty"`
+	IDs           []int64 `url:"ids,comma,omitempty"`
+	IsGetLongText int     `url:"isGetLongText,omitempty"`
+}
+
 // StatusRequest represetns a request to create a status.
 type StatusRequest struct {
-	Status      *string  `url:"status"`
-	Visible     *int     `url:"visible,omitempty"`
-	ListID      *int     `url:"list_id,omitempty"`
-	Lat         *float64 `url:"lat,omitempty"`
-	Long        *float64 `url:"long,omitempty"`
-	Annotations *string  `url:"annotations,omitempty"`
-	RealIP      *string  `url:"rip,omitempty"`
+	Status      *string     `url:"status"`
+	Visible     *Visibility `url:"visible,omitempty"`
+	ListID      *int64      `url:"list_id,omitempty"`
+	Lat         *float64    `url:"lat,omitempty"`
+	Long        *float64    `url:"long,omitempty"`
+	Annotations Annotations `url:"annotations,omitempty"`
+	RealIP      *string     `url:"rip,omitempty"`
+}
+
+// RepostRequest represents a request to repost a status.
+type RepostRequest struct {
+	ID          int64       `url:"id"`
+	Status      *string     `url:"status,omitempty"`
+	IsComment   *int        `url:"is_comment,omitempty"`
+	Annotations Annotations `url:"annotations,omitempty"`
+	RealIP      *string     `url:"rip,omitempty"`
 }
 
 // Timeline of a user. Passing the empty string will return
@@ -120,10 +208,15 @@ func (s *StatusesService) UserTimelineIDs(opt *StatusListOptions) (*TimelineIDs,
 	return timelineIDs, resp, err
 }
 
-// Create a Weibo Status.
+// Create a Weibo Status.  opt is validated before it is sent, see
+// StatusRequest.Validate.
 //
 // Weibo API docs: http://open.weibo.com/wiki/2/statuses/update
 func (s *StatusesService) Create(opt *StatusRequest) (*Status, *Response, error) {
+	if err := opt.Validate(); err != nil {
+		return nil, nil, err
+	}
+
 	u := "statuses/update.json"
 
 	req, err := s.client.NewRequest("POST", u, opt)
@@ -139,3 +232,144 @@ func (s *StatusesService) Create(opt *StatusRequest) (*Status, *Response, error)
 
 	return status, resp, err
 }
+
+// Repost a Weibo Status.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/statuses/repost
+func (s *StatusesService) Repost(opt *RepostRequest) (*Status, *Response, error) {
+	u := "statuses/repost.json"
+
+	req, err := s.client.NewRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(Status)
+	resp, err := s.client.Do(req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, err
+}
+
+// ShowLongText returns a single status along with its full text, which is
+// only set in LongText if the status is a long one.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/statuses/show
+func (s *StatusesService) ShowLongText(id int64) (*Status, *Response, error) {
+	u, err := addOptions("statuses/show.json", &showOptions{ID: id, IsGetLongText: 1})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	status := new(Status)
+	resp, err := s.client.Do(req, status)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return status, resp, err
+}
+
+// ShowBatch returns statuses by their IDs, at most 50 at a time.  If
+// longText is true, the full text of long statuses is set in LongText.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/statuses/show_batch
+func (s *StatusesService) ShowBatch(ids []int64, longText bool) ([]Status, *Response, error) {
+	opt := &showOptions{IDs: ids}
+	if longText {
+		opt.IsGetLongText = 1
+	}
+
+	u, err := addOptions("statuses/show_batch.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeline := &Timeline{}
+	resp, err := s.client.Do(req, timeline)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timeline.Statuses, resp, err
+}
+
+// ShowMany returns statuses by their IDs, keyed by ID.  The IDs are fetched
+// from statuses/show_batch, with a few batches in flight at a time; IDs
+// already being fetched by another ShowMany call are not fetched again, but
+// each call gets statuses of its own.  If some IDs failed, the statuses
+// found are returned along with a *BatchError, which reports ErrNotFound for
+// IDs of statuses which do not exist.
+func (s *StatusesService) ShowMany(ctx context.Context, ids []int64) (map[int64]*Status, error) {
+	return batchFetch[Status](ctx, s.client, "statuses/show_batch", ids, maxShowBatchIDs,
+		func(ctx context.Context, ids []int64) (map[int64]json.RawMessage, error) {
+			u, err := addOptions("statuses/show_batch.json", &showOptions{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+			return s.client.getBatch(ctx, u, "statuses")
+		})
+}
+
+// ExpandLongText fetches the full text of the long statuses in statuses,
+// including those reposted, which have IsLongText set but no LongText yet.
+// The statuses are fetched in batches of 50 and updated in place.  The
+// response of the last batch is returned.
+func (s *StatusesService) ExpandLongText(statuses []Status) (*Response, error) {
+	pending := make(map[int64][]*Status)
+	var ids []int64
+	add := func(status *Status) {
+		if status.ID == nil || status.IsLongText == nil || !*status.IsLongText || status.LongText != nil {
+			return
+		}
+		if _, ok := pending[*status.ID]; !ok {
+			ids = append(ids, *status.ID)
+		}
+		pending[*status.ID] = append(pending[*status.ID], status)
+	}
+	for i := range statuses {
+		add(&statuses[i])
+		if rt := statuses[i].RetweetedStatus; rt != nil {
+			add(rt)
+		}
+	}
+
+	var resp *Response
+	for len(ids) > 0 {
+		n := len(ids)
+		if n > maxShowBatchIDs {
+			n = maxShowBatchIDs
+		}
+
+		var expanded []Status
+		var err error
+		expanded, resp, err = s.ShowBatch(ids[:n], true)
+		if err != nil {
+			return resp, err
+		}
+
+		for _, e := range expanded {
+			if e.ID == nil || e.LongText == nil {
+				continue
+			}
+			for _, status := range pending[*e.ID] {
+				status.LongText = e.LongText
+			}
+		}
+		ids = ids[n:]
+	}
+
+	return resp, nil
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/larrylv/go-weibo/weibo/text"
 )
 
 func TestStatusesUserTimeline(t *testing.T) {
@@ -67,10 +70,8 @@ func TestStatusesUpdate(t *testing.T) {
 
 	text := "Hello, weibo!"
 
-	opt := &StatusRequest{
-		Status:  String(text),
-		Visible: Int(1),
-	}
+	opt := &StatusRequest{Status: String(text)}
+	opt.SetVisible(VisibleToSelf())
 
 	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "POST")
@@ -97,8 +98,153 @@ func TestStatusesUpdate(t *testing.T) {
 		t.Errorf("Statuses.Update returned error %v", err)
 	}
 
-	want := &Status{ID: Int64(1), Text: String("Hello, weibo!"), Visible: &Visible{VType: Int(1)}}
+	want := &Status{ID: Int64(1), Text: String("Hello, weibo!"), Visible: VisibleToSelf()}
 	if !reflect.DeepEqual(status, want) {
 		t.Errorf("Statuses.Update returned %+v, want %+v", status, want)
 	}
 }
+
+func TestStatusesRepost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	opt := &RepostRequest{
+		ID:        1,
+		Status:    String("repost"),
+		IsComment: Int(1),
+	}
+
+	mux.HandleFunc("/2/statuses/repost.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"id":         "1",
+			"status":     "repost",
+			"is_comment": "1",
+		})
+
+		fmt.Fprint(w, `{"id": 2, "text": "repost"}`)
+	})
+
+	status, _, err := client.Statuses.Repost(opt)
+
+	if err != nil {
+		t.Errorf("Statuses.Repost returned error %v", err)
+	}
+
+	want := &Status{ID: Int64(2), Text: String("repost")}
+	if !reflect.DeepEqual(status, want) {
+		t.Errorf("Statuses.Repost returned %+v, want %+v", status, want)
+	}
+}
+
+func TestStatus_Entities(t *testing.T) {
+	s := &Status{Text: String("@larrylv #golang#")}
+
+	want := []text.Entity{
+		{Kind: text.Mention, Text: "@larrylv", Value: "larrylv", Start: 0, End: 8},
+		{Kind: text.Topic, Text: "#golang#", Value: "golang", Start: 9, End: 17},
+	}
+	if got := s.Entities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Status.Entities returned %+v, want %+v", got, want)
+	}
+
+	if got := new(Status).Entities(); got != nil {
+		t.Errorf("Status.Entities returned %+v, want nil", got)
+	}
+}
+
+func TestStatusesShowLongText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"id":            "1",
+			"isGetLongText": "1",
+		})
+		fmt.Fprint(w, `{"id": 1, "text": "short...", "isLongText": true, "longText": {"longTextContent": "long text"}}`)
+	})
+
+	status, _, err := client.Statuses.ShowLongText(1)
+
+	if err != nil {
+		t.Errorf("Statuses.ShowLongText returned error: %v", err)
+	}
+
+	if got := status.FullText(); got != "long text" {
+		t.Errorf("Statuses.ShowLongText returned full text %q, want %q", got, "long text")
+	}
+}
+
+func TestStatusesShowBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"ids": "1,2",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 1}, {"id": 2}]}`)
+	})
+
+	statuses, _, err := client.Statuses.ShowBatch([]int64{1, 2}, false)
+
+	if err != nil {
+		t.Errorf("Statuses.ShowBatch returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(1)}, {ID: Int64(2)}}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Statuses.ShowBatch returned %+v, want %+v", statuses, want)
+	}
+}
+
+func TestStatusesExpandLongText(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/2/statuses/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+
+		ids := strings.Split(r.FormValue("ids"), ",")
+		if len(ids) > maxShowBatchIDs {
+			t.Errorf("Statuses.ExpandLongText requested %d ids, want at most %d", len(ids), maxShowBatchIDs)
+		}
+
+		var statuses []string
+		for _, id := range ids {
+			statuses = append(statuses, fmt.Sprintf(`{"id": %v, "isLongText": true, "longText": {"longTextContent": "full %v"}}`, id, id))
+		}
+		fmt.Fprintf(w, `{"statuses": [%v]}`, strings.Join(statuses, ","))
+	})
+
+	var statuses []Status
+	for i := 1; i <= 60; i++ {
+		statuses = append(statuses, Status{ID: Int64(i), Text: String("short"), IsLongText: Bool(true)})
+	}
+	statuses = append(statuses, Status{ID: Int64(100), Text: String("short"), IsLongText: Bool(false)})
+	statuses = append(statuses, Status{ID: Int64(101), RetweetedStatus: &Status{ID: Int64(1), IsLongText: Bool(true)}})
+
+	_, err := client.Statuses.ExpandLongText(statuses)
+
+	if err != nil {
+		t.Errorf("Statuses.ExpandLongText returned error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("Statuses.ExpandLongText sent %d requests, want 2", requests)
+	}
+	if got := statuses[59].FullText(); got != "full 60" {
+		t.Errorf("Statuses.ExpandLongText expanded %q, want %q", got, "full 60")
+	}
+	if got := statuses[60].FullText(); got != "short" {
+		t.Errorf("Statuses.ExpandLongText expanded %q, want %q", got, "short")
+	}
+	if got := statuses[61].RetweetedStatus.FullText(); got != "full 1" {
+		t.Errorf("Statuses.ExpandLongText expanded repost %q, want %q", got, "full 1")
+	}
+}
@@ -0,0 +1,195 @@
+package weibo
+
+import (
+	"fmt"
+)
+
+// SuggestionsService handles communication with the suggestion related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/users/hot
+type SuggestionsService struct {
+	client *Client
+}
+
+// SuggestedUser represents a user recommended by Weibo.
+type SuggestedUser struct {
+	User
+
+	// UID is set instead of ID by the may_interested method.
+	UID *int64 `json:"uid,omitempty"`
+
+	// Reason lists why the user is recommended, keyed by reason type,
+	// e.g. "f" for users followed by the authenticated user's friends.
+	Reason map[string]SuggestionReason `json:"reason,omitempty"`
+}
+
+// SuggestionReason represents a reason of a user recommendation.
+type SuggestionReason struct {
+	UIDs []int64 `json:"uid,omitempty"`
+	N    *int    `json:"n,omitempty"`
+}
+
+// SuggestedStatus represents a status recommended by Weibo.
+type SuggestedStatus struct {
+	Status
+
+	Reason *string `json:"reason,omitempty"`
+}
+
+// HotStatusesOptions specifies the parameters to the
+// SuggestionsService.HotStatuses method.
+type HotStatusesOptions struct {
+	// Type of the statuses, 1 for funny, 2 for cute pets, and so on.
+	Type int `url:"type"`
+
+	// IsPic limits the result to statuses with pictures when set to 1.
+	IsPic int `url:"is_pic,omitempty"`
+	ListOptions
+}
+
+// byStatusOptions specifies the parameters to the
+// SuggestionsService.ByStatus method.
+type byStatusOptions struct {
+	Content string `url:"content"`
+	Num     int    `url:"num,omitempty"`
+}
+
+// reorderRequest represents a request to reorder the home timeline.
+type reorderRequest struct {
+	Section int `url:"section"`
+}
+
+// notInterestedRequest represents a request to dismiss a recommended user.
+type notInterestedRequest struct {
+	UID string `url:"uid"`
+}
+
+// HotUsers lists the hot users of a category, e.g. "ent" or "sports".  An
+// empty category lists the default hot users.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/users/hot
+func (s *SuggestionsService) HotUsers(category string) ([]SuggestedUser, *Response, error) {
+	u := "suggestions/users/hot.json"
+	if category != "" {
+		u = fmt.Sprintf("%v?category=%v", u, category)
+	}
+	return s.users(u)
+}
+
+// MayInterested lists the users the authenticated user may be interested in.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/users/may_interested
+func (s *SuggestionsService) MayInterested(opt *ListOptions) ([]SuggestedUser, *Response, error) {
+	u, err := addOptions("suggestions/users/may_interested.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.users(u)
+}
+
+// ByStatus lists the users related to the content of a status.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/users/by_status
+func (s *SuggestionsService) ByStatus(content string, num int) ([]SuggestedUser, *Response, error) {
+	u, err := addOptions("suggestions/users/by_status.json", &byStatusOptions{Content: content, Num: num})
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.users(u)
+}
+
+// HotStatuses lists the hot statuses of a type.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/statuses/hot
+func (s *SuggestionsService) HotStatuses(opt *HotStatusesOptions) ([]SuggestedStatus, *Response, error) {
+	u, err := addOptions("suggestions/statuses/hot.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.statuses(u)
+}
+
+// Reorder returns the home timeline of the authenticated user, ordered by
+// Weibo's recommendation.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/statuses/reorder
+func (s *SuggestionsService) Reorder(section int) (*Timeline, *Response, error) {
+	u := "suggestions/statuses/reorder.json"
+
+	req, err := s.client.NewRequest("POST", u, &reorderRequest{Section: section})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	timeline := &Timeline{}
+	resp, err := s.client.Do(req, timeline)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return timeline, resp, err
+}
+
+// HotFavorites lists the statuses favorited the most.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/favorites/hot
+func (s *SuggestionsService) HotFavorites(opt *ListOptions) ([]SuggestedStatus, *Response, error) {
+	u, err := addOptions("suggestions/favorites/hot.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.statuses(u)
+}
+
+// NotInterested dismisses a recommended user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/suggestions/users/not_interested
+func (s *SuggestionsService) NotInterested(uid string) (*User, *Response, error) {
+	u := "suggestions/users/not_interested.json"
+
+	req, err := s.client.NewRequest("POST", u, &notInterestedRequest{UID: uid})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// users sends a GET request to u and decodes the returned users.
+func (s *SuggestionsService) users(u string) ([]SuggestedUser, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := new([]SuggestedUser)
+	resp, err := s.client.Do(req, users)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *users, resp, err
+}
+
+// statuses sends a GET request to u and decodes the returned statuses.
+func (s *SuggestionsService) statuses(u string) ([]SuggestedStatus, *Response, error) {
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	statuses := new([]SuggestedStatus)
+	resp, err := s.client.Do(req, statuses)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *statuses, resp, err
+}
@@ -0,0 +1,178 @@
+package weibo
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSuggestionsHotUsers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/users/hot.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"category": "sports",
+		})
+		fmt.Fprint(w, `[{"id": 42, "name": "larrylv"}]`)
+	})
+
+	users, _, err := client.Suggestions.HotUsers("sports")
+
+	if err != nil {
+		t.Errorf("Suggestions.HotUsers returned error: %v", err)
+	}
+
+	want := []SuggestedUser{{User: User{ID: Int(42), Name: String("larrylv")}}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Suggestions.HotUsers returned %+v, want %+v", users, want)
+	}
+}
+
+func TestSuggestionsMayInterested(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/users/may_interested.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"count": "1",
+		})
+		fmt.Fprint(w, `[{"uid": 42, "reason": {"f": {"uid": [1, 2], "n": 2}}}]`)
+	})
+
+	users, _, err := client.Suggestions.MayInterested(&ListOptions{PerPage: 1})
+
+	if err != nil {
+		t.Errorf("Suggestions.MayInterested returned error: %v", err)
+	}
+
+	want := []SuggestedUser{{
+		UID:    Int64(42),
+		Reason: map[string]SuggestionReason{"f": {UIDs: []int64{1, 2}, N: Int(2)}},
+	}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Suggestions.MayInterested returned %+v, want %+v", users, want)
+	}
+}
+
+func TestSuggestionsByStatus(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/users/by_status.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"content": "golang",
+			"num":     "5",
+		})
+		fmt.Fprint(w, `[{"id": 42}]`)
+	})
+
+	users, _, err := client.Suggestions.ByStatus("golang", 5)
+
+	if err != nil {
+		t.Errorf("Suggestions.ByStatus returned error: %v", err)
+	}
+
+	want := []SuggestedUser{{User: User{ID: Int(42)}}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Suggestions.ByStatus returned %+v, want %+v", users, want)
+	}
+}
+
+func TestSuggestionsHotStatuses(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/statuses/hot.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"type":   "1",
+			"is_pic": "1",
+		})
+		fmt.Fprint(w, `[{"id": 1, "text": "hot"}]`)
+	})
+
+	statuses, _, err := client.Suggestions.HotStatuses(&HotStatusesOptions{Type: 1, IsPic: 1})
+
+	if err != nil {
+		t.Errorf("Suggestions.HotStatuses returned error: %v", err)
+	}
+
+	want := []SuggestedStatus{{Status: Status{ID: Int64(1), Text: String("hot")}}}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Suggestions.HotStatuses returned %+v, want %+v", statuses, want)
+	}
+}
+
+func TestSuggestionsReorder(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/statuses/reorder.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"section": "3600",
+		})
+		fmt.Fprint(w, `{"statuses": [{"id": 1}], "total_number": 1}`)
+	})
+
+	timeline, _, err := client.Suggestions.Reorder(3600)
+
+	if err != nil {
+		t.Errorf("Suggestions.Reorder returned error: %v", err)
+	}
+
+	want := []Status{{ID: Int64(1)}}
+	if !reflect.DeepEqual(timeline.Statuses, want) {
+		t.Errorf("Suggestions.Reorder returned %+v, want %+v", timeline.Statuses, want)
+	}
+}
+
+func TestSuggestionsHotFavorites(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/favorites/hot.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": 1}]`)
+	})
+
+	statuses, _, err := client.Suggestions.HotFavorites(nil)
+
+	if err != nil {
+		t.Errorf("Suggestions.HotFavorites returned error: %v", err)
+	}
+
+	want := []SuggestedStatus{{Status: Status{ID: Int64(1)}}}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Suggestions.HotFavorites returned %+v, want %+v", statuses, want)
+	}
+}
+
+func TestSuggestionsNotInterested(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/suggestions/users/not_interested.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"uid": "42",
+		})
+		fmt.Fprint(w, `{"id": 42}`)
+	})
+
+	user, _, err := client.Suggestions.NotInterested("42")
+
+	if err != nil {
+		t.Errorf("Suggestions.NotInterested returned error: %v", err)
+	}
+
+	want := &User{ID: Int(42)}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("Suggestions.NotInterested returned %+v, want %+v", user, want)
+	}
+}
@@ -0,0 +1,250 @@
+package weibo
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// TagsService handles communication with the tag related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags
+type TagsService struct {
+	client *Client
+}
+
+// Tag represents a Weibo user tag.
+type Tag struct {
+	ID     *int64  `json:"id,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	Weight *int    `json:"weight,omitempty"`
+}
+
+// UnmarshalJSON decodes a tag object as returned by the Weibo API.  Tags are
+// keyed by their ID, i.e. {"123": "golang", "weight": "20"}, while tag
+// suggestions use {"id": "123", "value": "golang"} instead.  The
+// {"id": 123, "name": "golang", "weight": 20} encoding of Tag is decoded too.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	for k, v := range m {
+		switch k {
+		case "weight":
+			weight, err := unmarshalNumber(v)
+			if err != nil {
+				return err
+			}
+			t.Weight = Int(int(weight))
+		case "id":
+			id, err := unmarshalNumber(v)
+			if err != nil {
+				return err
+			}
+			t.ID = &id
+		case "name", "value":
+			var name string
+			if err := json.Unmarshal(v, &name); err != nil {
+				return err
+			}
+			t.Name = &name
+		default:
+			id, err := strconv.ParseInt(k, 10, 64)
+			if err != nil {
+				// not a tag ID key, e.g. "flag"
+				continue
+			}
+			var name string
+			if err := json.Unmarshal(v, &name); err != nil {
+				return err
+			}
+			t.ID, t.Name = &id, &name
+		}
+	}
+
+	return nil
+}
+
+// unmarshalNumber decodes a JSON number which may also be encoded as a
+// string, as the Weibo API does for some numeric fields.
+func unmarshalNumber(data []byte) (int64, error) {
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return 0, err
+	}
+	return n.Int64()
+}
+
+// UserTags represents the tags of a Weibo user.
+type UserTags struct {
+	ID   *int64 `json:"id,omitempty"`
+	Tags []Tag  `json:"tags,omitempty"`
+}
+
+// tagID represents a tag ID as returned by the create and destroy methods.
+type tagID struct {
+	TagID int64 `json:"tagid"`
+}
+
+// TagListOptions specifies the optional parameters to the
+// TagsService.List method.
+type TagListOptions struct {
+	UID string `url:"uid,omitempty"`
+	ListOptions
+}
+
+// tagsBatchOptions specifies the parameters to the TagsService.ListBatch
+// method.
+type tagsBatchOptions struct {
+	UIDs []string `url:"uids,comma"`
+}
+
+// TagRequest represents a request to create tags.
+type TagRequest struct {
+	Tags []string `url:"tags,comma"`
+}
+
+// tagDestroyRequest represents a request to destroy a single tag.
+type tagDestroyRequest struct {
+	TagID int64 `url:"tag_id"`
+}
+
+// tagDestroyBatchRequest represents a request to destroy tags in batch.
+type tagDestroyBatchRequest struct {
+	IDs []int64 `url:"ids,comma"`
+}
+
+// List the tags of a user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags
+func (s *TagsService) List(opt *TagListOptions) ([]Tag, *Response, error) {
+	u, err := addOptions("tags.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags := new([]Tag)
+	resp, err := s.client.Do(req, tags)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *tags, resp, err
+}
+
+// ListBatch lists the tags of multiple users.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags/tags_batch
+func (s *TagsService) ListBatch(uids []string) ([]UserTags, *Response, error) {
+	u, err := addOptions("tags/tags_batch.json", &tagsBatchOptions{UIDs: uids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	userTags := new([]UserTags)
+	resp, err := s.client.Do(req, userTags)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *userTags, resp, err
+}
+
+// Suggestions lists the tags suggested for the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags/suggestions
+func (s *TagsService) Suggestions(opt *ListOptions) ([]Tag, *Response, error) {
+	u, err := addOptions("tags/suggestions.json", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tags := new([]Tag)
+	resp, err := s.client.Do(req, tags)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return *tags, resp, err
+}
+
+// Create tags for the authenticated user, returning the IDs of the
+// created tags.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags/create
+func (s *TagsService) Create(opt *TagRequest) ([]int64, *Response, error) {
+	u := "tags/create.json"
+
+	req, err := s.client.NewRequest("POST", u, opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ids := new([]tagID)
+	resp, err := s.client.Do(req, ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tagIDs(*ids), resp, err
+}
+
+// Destroy a tag of the authenticated user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags/destroy
+func (s *TagsService) Destroy(id int64) (*Response, error) {
+	u := "tags/destroy.json"
+
+	req, err := s.client.NewRequest("POST", u, &tagDestroyRequest{TagID: id})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
+
+// DestroyBatch destroys tags of the authenticated user in batch, returning
+// the IDs of the destroyed tags.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/tags/destroy_batch
+func (s *TagsService) DestroyBatch(ids []int64) ([]int64, *Response, error) {
+	u := "tags/destroy_batch.json"
+
+	req, err := s.client.NewRequest("POST", u, &tagDestroyBatchRequest{IDs: ids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	destroyed := new([]tagID)
+	resp, err := s.client.Do(req, destroyed)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return tagIDs(*destroyed), resp, err
+}
+
+func tagIDs(ids []tagID) []int64 {
+	result := make([]int64, len(ids))
+	for i, id := range ids {
+		result[i] = id.TagID
+	}
+	return result
+}
@@ -0,0 +1,191 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTag_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Tag
+	}{
+		{`{"1001": "golang", "weight": "20", "flag": "0"}`, Tag{ID: Int64(1001), Name: String("golang"), Weight: Int(20)}},
+		{`{"1001": "golang", "weight": 20}`, Tag{ID: Int64(1001), Name: String("golang"), Weight: Int(20)}},
+		{`{"id": "1001", "value": "golang"}`, Tag{ID: Int64(1001), Name: String("golang")}},
+		{`{"id": 1001, "name": "golang", "weight": 20}`, Tag{ID: Int64(1001), Name: String("golang"), Weight: Int(20)}},
+	}
+
+	for _, tt := range tests {
+		var tag Tag
+		if err := json.Unmarshal([]byte(tt.in), &tag); err != nil {
+			t.Errorf("json.Unmarshal(%v) returned error: %v", tt.in, err)
+		}
+		if !reflect.DeepEqual(tag, tt.want) {
+			t.Errorf("json.Unmarshal(%v) = %+v, want %+v", tt.in, tag, tt.want)
+		}
+	}
+}
+
+func TestTag_roundTrip(t *testing.T) {
+	want := Tag{ID: Int64(1001), Name: String("golang"), Weight: Int(20)}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal returned error: %v", err)
+	}
+	var got Tag
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s) returned error: %v", data, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("json.Unmarshal(%s) = %+v, want %+v", data, got, want)
+	}
+}
+
+func TestTagsList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uid":   "42",
+			"count": "10",
+		})
+		fmt.Fprint(w, `[{"1001": "golang", "weight": "20"}, {"1002": "weibo", "weight": "10"}]`)
+	})
+
+	opt := &TagListOptions{UID: "42", ListOptions: ListOptions{PerPage: 10}}
+	tags, _, err := client.Tags.List(opt)
+
+	if err != nil {
+		t.Errorf("Tags.List returned error: %v", err)
+	}
+
+	want := []Tag{
+		{ID: Int64(1001), Name: String("golang"), Weight: Int(20)},
+		{ID: Int64(1002), Name: String("weibo"), Weight: Int(10)},
+	}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Tags.List returned %+v, want %+v", tags, want)
+	}
+}
+
+func TestTagsListBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags/tags_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{
+			"uids": "42,43",
+		})
+		fmt.Fprint(w, `[{"id": 42, "tags": [{"1001": "golang", "weight": "20"}]}, {"id": 43, "tags": []}]`)
+	})
+
+	userTags, _, err := client.Tags.ListBatch([]string{"42", "43"})
+
+	if err != nil {
+		t.Errorf("Tags.ListBatch returned error: %v", err)
+	}
+
+	want := []UserTags{
+		{ID: Int64(42), Tags: []Tag{{ID: Int64(1001), Name: String("golang"), Weight: Int(20)}}},
+		{ID: Int64(43), Tags: []Tag{}},
+	}
+	if !reflect.DeepEqual(userTags, want) {
+		t.Errorf("Tags.ListBatch returned %+v, want %+v", userTags, want)
+	}
+}
+
+func TestTagsSuggestions(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags/suggestions.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `[{"id": "1001", "value": "golang"}]`)
+	})
+
+	tags, _, err := client.Tags.Suggestions(nil)
+
+	if err != nil {
+		t.Errorf("Tags.Suggestions returned error: %v", err)
+	}
+
+	want := []Tag{{ID: Int64(1001), Name: String("golang")}}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("Tags.Suggestions returned %+v, want %+v", tags, want)
+	}
+}
+
+func TestTagsCreate(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags/create.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"tags": "golang,weibo",
+		})
+		fmt.Fprint(w, `[{"tagid": 1001}, {"tagid": 1002}]`)
+	})
+
+	ids, _, err := client.Tags.Create(&TagRequest{Tags: []string{"golang", "weibo"}})
+
+	if err != nil {
+		t.Errorf("Tags.Create returned error: %v", err)
+	}
+
+	want := []int64{1001, 1002}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Tags.Create returned %+v, want %+v", ids, want)
+	}
+}
+
+func TestTagsDestroy(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags/destroy.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"tag_id": "1001",
+		})
+		fmt.Fprint(w, `{"result": true}`)
+	})
+
+	_, err := client.Tags.Destroy(1001)
+
+	if err != nil {
+		t.Errorf("Tags.Destroy returned error: %v", err)
+	}
+}
+
+func TestTagsDestroyBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/tags/destroy_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"ids": "1001,1002",
+		})
+		fmt.Fprint(w, `[{"tagid": 1001}, {"tagid": 1002}]`)
+	})
+
+	ids, _, err := client.Tags.DestroyBatch([]int64{1001, 1002})
+
+	if err != nil {
+		t.Errorf("Tags.DestroyBatch returned error: %v", err)
+	}
+
+	want := []int64{1001, 1002}
+	if !reflect.DeepEqual(ids, want) {
+		t.Errorf("Tags.DestroyBatch returned %+v, want %+v", ids, want)
+	}
+}
@@ -0,0 +1,215 @@
+package text
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// Linker builds the links of rendered entities.  A nil field falls back to
+// the corresponding DefaultLinker field.
+type Linker struct {
+	// Mention returns the link of a screen name.
+	Mention func(name string) string
+
+	// Topic returns the link of a topic name.
+	Topic func(name string) string
+
+	// URL returns the link of a URL in status text, which allows a short
+	// URL to be expanded to its target.
+	URL func(url string) string
+
+	// Emoticon returns the image of an emoticon phrase, including the
+	// brackets, e.g. "[哈哈]".  It returns false for unknown phrases, which
+	// are rendered as plain text.
+	Emoticon func(phrase string) (src string, ok bool)
+}
+
+// DefaultLinker links mentions and topics to weibo.com, keeps URLs as they
+// are and renders no emoticon images.
+var DefaultLinker = Linker{
+	Mention: func(name string) string {
+		return "https://weibo.com/n/" + url.PathEscape(name)
+	},
+	Topic: func(name string) string {
+		return "https://s.weibo.com/weibo?q=" + url.QueryEscape("#"+name+"#")
+	},
+	URL: func(u string) string {
+		return u
+	},
+	Emoticon: func(phrase string) (string, bool) {
+		return "", false
+	},
+}
+
+// withDefaults returns a copy of l with nil fields set from DefaultLinker.
+func (l *Linker) withDefaults() Linker {
+	d := DefaultLinker
+	if l == nil {
+		return d
+	}
+	if l.Mention != nil {
+		d.Mention = l.Mention
+	}
+	if l.Topic != nil {
+		d.Topic = l.Topic
+	}
+	if l.URL != nil {
+		d.URL = l.URL
+	}
+	if l.Emoticon != nil {
+		d.Emoticon = l.Emoticon
+	}
+	return d
+}
+
+// HTML renders s as HTML, linking its entities with l, which may be nil to
+// use DefaultLinker.  All text is HTML escaped, and newlines become <br>.
+func HTML(s string, l *Linker) string {
+	links := l.withDefaults()
+
+	var b strings.Builder
+	for _, e := range Tokenize(s) {
+		switch e.Kind {
+		case Mention:
+			writeHTMLLink(&b, links.Mention(e.Value), e.Text)
+		case Topic:
+			writeHTMLLink(&b, links.Topic(e.Value), e.Text)
+		case URL:
+			writeHTMLLink(&b, links.URL(e.Value), e.Text)
+		case Emoticon:
+			if src, ok := links.Emoticon(e.Text); ok {
+				phrase := html.EscapeString(e.Text)
+				b.WriteString(`<img src="` + html.EscapeString(src) + `" alt="` + phrase + `" title="` + phrase + `">`)
+				break
+			}
+			fallthrough
+		default:
+			b.WriteString(strings.ReplaceAll(html.EscapeString(e.Text), "\n", "<br>"))
+		}
+	}
+	return b.String()
+}
+
+func writeHTMLLink(b *strings.Builder, href, text string) {
+	b.WriteString(`<a href="` + html.EscapeString(safeHref(href)) + `">` + html.EscapeString(text) + `</a>`)
+}
+
+// safeHref returns href, or "#" if its scheme could run script.
+func safeHref(href string) string {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return "#"
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "", "http", "https", "mailto":
+		return href
+	}
+	return "#"
+}
+
+// markdownEscaper escapes the characters with a meaning in Markdown.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`(`, `\(`, `)`, `\)`, `#`, `\#`, `<`, `\<`, `>`, `\>`, `!`, `\!`,
+	`|`, `\|`, `~`, `\~`,
+)
+
+// markdownURLEscaper escapes the characters that would end a Markdown link
+// destination.
+var markdownURLEscaper = strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E")
+
+// Markdown renders s as Markdown, linking its entities with l, which may be
+// nil to use DefaultLinker.  All text is escaped, including the list, heading
+// and setext underline markers at the start of lines, and newlines become hard
+// line breaks, like the <br> of HTML.
+func Markdown(s string, l *Linker) string {
+	links := l.withDefaults()
+
+	w := &markdownWriter{lineStart: true}
+	for _, e := range Tokenize(s) {
+		switch e.Kind {
+		case Mention:
+			w.link("", links.Mention(e.Value), e.Text)
+		case Topic:
+			w.link("", links.Topic(e.Value), e.Text)
+		case URL:
+			w.link("", links.URL(e.Value), e.Text)
+		case Emoticon:
+			if src, ok := links.Emoticon(e.Text); ok {
+				w.link("!", src, e.Text)
+				break
+			}
+			fallthrough
+		default:
+			w.text(e.Text)
+		}
+	}
+	w.end()
+	return w.String()
+}
+
+// markdownWriter writes escaped Markdown.
+type markdownWriter struct {
+	strings.Builder
+	lineStart bool // whether the next text starts a line
+	breaks    int  // newlines not written yet
+}
+
+// flush writes the pending newlines as hard line breaks.
+func (w *markdownWriter) flush() {
+	for ; w.breaks > 0; w.breaks-- {
+		w.WriteString("\\\n")
+	}
+}
+
+// end writes the trailing newlines, which cannot be hard line breaks.
+func (w *markdownWriter) end() {
+	w.WriteString(strings.Repeat("\n", w.breaks))
+	w.breaks = 0
+}
+
+func (w *markdownWriter) text(s string) {
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			w.breaks++
+			w.lineStart = true
+		}
+		if line == "" {
+			continue
+		}
+		w.flush()
+		if w.lineStart {
+			w.WriteString(escapeLineStart(line))
+		} else {
+			w.WriteString(markdownEscaper.Replace(line))
+		}
+		w.lineStart = false
+	}
+}
+
+func (w *markdownWriter) link(prefix, href, text string) {
+	w.flush()
+	w.WriteString(prefix + "[" + markdownEscaper.Replace(text) + "](" + markdownURLEscaper.Replace(safeHref(href)) + ")")
+	w.lineStart = false
+}
+
+// escapeLineStart escapes line, which starts a line, including the bullet
+// list, ordered list and setext heading markers it may start with.
+func escapeLineStart(line string) string {
+	rest := strings.TrimLeft(line, " \t")
+	indent := line[:len(line)-len(rest)]
+
+	switch {
+	case rest == "":
+	case rest[0] == '-' || rest[0] == '+' || rest[0] == '=':
+		return indent + `\` + rest[:1] + markdownEscaper.Replace(rest[1:])
+	default:
+		after := strings.TrimLeft(rest, "0123456789")
+		if len(after) < len(rest) && (after == "." || strings.HasPrefix(after, ". ") || strings.HasPrefix(after, ".\t")) {
+			n := len(rest) - len(after)
+			return indent + rest[:n] + `\.` + markdownEscaper.Replace(after[1:])
+		}
+	}
+	return indent + markdownEscaper.Replace(rest)
+}
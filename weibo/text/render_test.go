@@ -0,0 +1,91 @@
+package text
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "hello"},
+		{"@新浪微博 hi", `<a href="https://weibo.com/n/%E6%96%B0%E6%B5%AA%E5%BE%AE%E5%8D%9A">@新浪微博</a> hi`},
+		{"#golang#", `<a href="https://s.weibo.com/weibo?q=%23golang%23">#golang#</a>`},
+		{"http://t.cn/a?b=1&c=2", `<a href="http://t.cn/a?b=1&amp;c=2">http://t.cn/a?b=1&amp;c=2</a>`},
+		{"[哈哈]", "[哈哈]"},
+		{"<script>alert(\"x\")</script>", "&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;"},
+		{"#<b>\"#", `<a href="https://s.weibo.com/weibo?q=%23%3Cb%3E%22%23">#&lt;b&gt;&#34;#</a>`},
+		{"a\nb", "a<br>b"},
+	}
+
+	for _, tt := range tests {
+		if got := HTML(tt.in, nil); got != tt.want {
+			t.Errorf("HTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestHTML_linker(t *testing.T) {
+	l := &Linker{
+		URL: func(u string) string {
+			return "https://example.com/expanded"
+		},
+		Mention: func(name string) string {
+			return "javascript:alert(1)"
+		},
+		Emoticon: func(phrase string) (string, bool) {
+			return `http://img.t.sinajs.cn/"haha".gif`, phrase == "[哈哈]"
+		},
+	}
+
+	in := "@a http://t.cn/a [哈哈][嘻嘻] #t#"
+	want := `<a href="#">@a</a> <a href="https://example.com/expanded">http://t.cn/a</a> ` +
+		`<img src="http://img.t.sinajs.cn/&#34;haha&#34;.gif" alt="[哈哈]" title="[哈哈]">[嘻嘻] ` +
+		`<a href="https://s.weibo.com/weibo?q=%23t%23">#t#</a>`
+	if got := HTML(in, l); got != want {
+		t.Errorf("HTML(%q) = %q, want %q", in, got, want)
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	l := &Linker{
+		Emoticon: func(phrase string) (string, bool) {
+			return "http://img.t.sinajs.cn/haha.gif", phrase == "[哈哈]"
+		},
+	}
+
+	tests := []struct {
+		in, want string
+	}{
+		{"hello", "hello"},
+		{"*bold* _it_ [x](y)", `\*bold\* \_it\_ \[x\]\(y\)`},
+		{"@larry_lv", `[@larry\_lv](https://weibo.com/n/larry_lv)`},
+		{"#微博#", "[\\#微博\\#](https://s.weibo.com/weibo?q=%23%E5%BE%AE%E5%8D%9A%23)"},
+		{"http://t.cn/a_(b)", `[http://t.cn/a\_\(b](http://t.cn/a_%28b)\)`},
+		{"[哈哈]", `![\[哈哈\]](http://img.t.sinajs.cn/haha.gif)`},
+		{"<img src=x>", `\<img src=x\>`},
+		{"- item", `\- item`},
+		{"+ item", `\+ item`},
+		{"  - item", `  \- item`},
+		{"1. item", `1\. item`},
+		{"10.", `10\.`},
+		{"2026.10 a - b 1. c", `2026.10 a - b 1. c`},
+		{"title\n===", "title\\\n\\==="},
+		{"title\n---", "title\\\n\\---"},
+		{"a\nb\n\nc", "a\\\nb\\\n\\\nc"},
+		{"a\n@b", "a\\\n[@b](https://weibo.com/n/b)"},
+		{"@b\n- c", "[@b](https://weibo.com/n/b)\\\n\\- c"},
+		{"a\n", "a\n"},
+	}
+
+	for _, tt := range tests {
+		if got := Markdown(tt.in, l); got != tt.want {
+			t.Errorf("Markdown(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	if got := Markdown("#t#", nil); !strings.HasPrefix(got, `[\#t\#](`) {
+		t.Errorf("Markdown with nil Linker = %q", got)
+	}
+}
@@ -0,0 +1,211 @@
+// Package text tokenizes the text of Weibo statuses into entities:
+// @mentions, #topics#, URLs and [emoticons].
+//
+// Offsets are counted in runes rather than bytes, so they match the
+// positions a user would see in the Chinese text of a status.
+package text
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Kind is the kind of an Entity.
+type Kind int
+
+const (
+	Plain    Kind = iota // plain text between other entities
+	Mention              // @name
+	Topic                // #topic#
+	URL                  // http://t.cn/...
+	Emoticon             // [哈哈]
+)
+
+var kindNames = []string{"Plain", "Mention", "Topic", "URL", "Emoticon"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return "Kind(" + strconv.Itoa(int(k)) + ")"
+	}
+	return kindNames[k]
+}
+
+// Entity represents a token of status text.
+type Entity struct {
+	Kind Kind
+
+	// Text is the entity as it appears in the status, including markers
+	// such as "@" or the surrounding "#".
+	Text string
+
+	// Value is Text without its markers: the screen name of a Mention, the
+	// name of a Topic, the phrase of an Emoticon without brackets.  For
+	// URL and Plain entities it equals Text.
+	Value string
+
+	// Start and End are the rune offsets of the entity in the status text,
+	// with End being exclusive.
+	Start, End int
+}
+
+// maxEmoticonLength is the maximum length in runes of an emoticon phrase,
+// excluding the brackets.
+const maxEmoticonLength = 10
+
+// Tokenize splits s into entities, including the Plain text between them,
+// so that concatenating the Text of the result yields s.
+func Tokenize(s string) []Entity {
+	runes := []rune(s)
+
+	var entities []Entity
+	plainStart := 0
+	flush := func(end int) {
+		if end > plainStart {
+			t := string(runes[plainStart:end])
+			entities = append(entities, Entity{Kind: Plain, Text: t, Value: t, Start: plainStart, End: end})
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		e, ok := scan(runes, i)
+		if !ok {
+			i++
+			continue
+		}
+
+		flush(i)
+		entities = append(entities, e)
+		i = e.End
+		plainStart = i
+	}
+	flush(len(runes))
+
+	return entities
+}
+
+// Extract returns the entities of s, leaving out plain text.
+func Extract(s string) []Entity {
+	var entities []Entity
+	for _, e := range Tokenize(s) {
+		if e.Kind != Plain {
+			entities = append(entities, e)
+		}
+	}
+	return entities
+}
+
+// scan tries to read an entity starting at runes[i].
+func scan(runes []rune, i int) (Entity, bool) {
+	switch runes[i] {
+	case 'h', 'H':
+		return scanURL(runes, i)
+	case '@':
+		return scanMention(runes, i)
+	case '#':
+		return scanTopic(runes, i)
+	case '[':
+		return scanEmoticon(runes, i)
+	}
+	return Entity{}, false
+}
+
+func newEntity(kind Kind, runes []rune, start, end int, value string) Entity {
+	return Entity{Kind: kind, Text: string(runes[start:end]), Value: value, Start: start, End: end}
+}
+
+// urlPrefixLength returns the length of the "http://" or "https://" prefix
+// runes[i:] starts with, or 0 if it starts with neither.
+func urlPrefixLength(runes []rune, i int) int {
+	for _, prefix := range []string{"http://", "https://"} {
+		if len(runes)-i >= len(prefix) && strings.EqualFold(string(runes[i:i+len(prefix)]), prefix) {
+			return len(prefix)
+		}
+	}
+	return 0
+}
+
+// isURLRune reports whether r may appear in a URL.  URLs in status text are
+// ASCII, so any Chinese punctuation ends them.
+func isURLRune(r rune) bool {
+	if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+		return true
+	}
+	return strings.ContainsRune("-._~:/?#@!$&'()*+,;=%", r)
+}
+
+func scanURL(runes []rune, i int) (Entity, bool) {
+	n := urlPrefixLength(runes, i)
+	if n == 0 {
+		return Entity{}, false
+	}
+
+	end := i + n
+	for end < len(runes) && isURLRune(runes[end]) {
+		end++
+	}
+	// trailing punctuation most likely belongs to the sentence
+	for end > i+n && strings.ContainsRune(".,;:!?'()", runes[end-1]) {
+		end--
+	}
+	if end == i+n {
+		return Entity{}, false
+	}
+
+	return newEntity(URL, runes, i, end, string(runes[i:end])), true
+}
+
+// isNameRune reports whether r may appear in a screen name: letters
+// (including Chinese characters), digits, "_" and "-".
+func isNameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
+
+func scanMention(runes []rune, i int) (Entity, bool) {
+	// an "@" after an ASCII word, as in an email address, is not a mention,
+	// while one right after Chinese text, as in "转发@name", is
+	if i > 0 && runes[i-1] < unicode.MaxASCII && (isNameRune(runes[i-1]) || runes[i-1] == '.') {
+		return Entity{}, false
+	}
+
+	end := i + 1
+	for end < len(runes) && isNameRune(runes[end]) {
+		end++
+	}
+	if end == i+1 {
+		return Entity{}, false
+	}
+
+	return newEntity(Mention, runes, i, end, string(runes[i+1:end])), true
+}
+
+func scanTopic(runes []rune, i int) (Entity, bool) {
+	for end := i + 1; end < len(runes); end++ {
+		switch {
+		case runes[end] == '#':
+			name := strings.TrimSpace(string(runes[i+1 : end]))
+			if name == "" {
+				return Entity{}, false
+			}
+			return newEntity(Topic, runes, i, end+1, name), true
+		case runes[end] == '\n', urlPrefixLength(runes, end) > 0:
+			return Entity{}, false
+		}
+	}
+	return Entity{}, false
+}
+
+func scanEmoticon(runes []rune, i int) (Entity, bool) {
+	for end := i + 1; end < len(runes) && end-i-1 <= maxEmoticonLength; end++ {
+		switch r := runes[end]; {
+		case r == ']':
+			if end == i+1 {
+				return Entity{}, false
+			}
+			return newEntity(Emoticon, runes, i, end+1, string(runes[i+1:end])), true
+		case r == '[', unicode.IsSpace(r):
+			return Entity{}, false
+		}
+	}
+	return Entity{}, false
+}
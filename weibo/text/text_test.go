@@ -0,0 +1,84 @@
+package text
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	in := "@larrylv 你好[哈哈]"
+	want := []Entity{
+		{Kind: Mention, Text: "@larrylv", Value: "larrylv", Start: 0, End: 8},
+		{Kind: Plain, Text: " 你好", Value: " 你好", Start: 8, End: 11},
+		{Kind: Emoticon, Text: "[哈哈]", Value: "哈哈", Start: 11, End: 15},
+	}
+
+	got := Tokenize(in)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Tokenize(%q) = %+v, want %+v", in, got, want)
+	}
+
+	var texts []string
+	for _, e := range got {
+		texts = append(texts, e.Text)
+	}
+	if joined := strings.Join(texts, ""); joined != in {
+		t.Errorf("Tokenize(%q) texts join to %q", in, joined)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Entity
+	}{
+		{"hello weibo", nil},
+		// Chinese punctuation ends a mention
+		{"转发@新浪微博：好", []Entity{{Kind: Mention, Text: "@新浪微博", Value: "新浪微博", Start: 2, End: 7}}},
+		{"@a_b-c，hi", []Entity{{Kind: Mention, Text: "@a_b-c", Value: "a_b-c", Start: 0, End: 6}}},
+		{"好//@larrylv:赞", []Entity{{Kind: Mention, Text: "@larrylv", Value: "larrylv", Start: 3, End: 11}}},
+		// email addresses are not mentions
+		{"mail foo@example.com", nil},
+		{"@ alone", nil},
+		// topics use a pair of hashes and may contain spaces
+		{"#微博 开放平台#好", []Entity{{Kind: Topic, Text: "#微博 开放平台#", Value: "微博 开放平台", Start: 0, End: 9}}},
+		{"#unclosed topic", nil},
+		{"##", nil},
+		{"#a\nb#", nil},
+		// URLs end at non-ASCII characters and trailing punctuation
+		{"看http://t.cn/zOXAaic。", []Entity{{Kind: URL, Text: "http://t.cn/zOXAaic", Value: "http://t.cn/zOXAaic", Start: 1, End: 20}}},
+		{"(https://t.cn/a).", []Entity{{Kind: URL, Text: "https://t.cn/a", Value: "https://t.cn/a", Start: 1, End: 15}}},
+		{"http://", nil},
+		// a hash inside a URL does not open a topic
+		{"http://t.cn/a#b #c#", []Entity{
+			{Kind: URL, Text: "http://t.cn/a#b", Value: "http://t.cn/a#b", Start: 0, End: 15},
+			{Kind: Topic, Text: "#c#", Value: "c", Start: 16, End: 19},
+		}},
+		{"#a http://t.cn/b#", []Entity{{Kind: URL, Text: "http://t.cn/b#", Value: "http://t.cn/b#", Start: 3, End: 17}}},
+		// a mention inside a topic is part of the topic
+		{"#@a#", []Entity{{Kind: Topic, Text: "#@a#", Value: "@a", Start: 0, End: 4}}},
+		{"[哈哈][good]", []Entity{
+			{Kind: Emoticon, Text: "[哈哈]", Value: "哈哈", Start: 0, End: 4},
+			{Kind: Emoticon, Text: "[good]", Value: "good", Start: 4, End: 10},
+		}},
+		{"[] [a b] [[哈]", []Entity{{Kind: Emoticon, Text: "[哈]", Value: "哈", Start: 10, End: 13}}},
+		{"[" + strings.Repeat("a", 11) + "]", nil},
+	}
+
+	for _, tt := range tests {
+		got := Extract(tt.in)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Extract(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if got := Topic.String(); got != "Topic" {
+		t.Errorf("Topic.String() = %q, want %q", got, "Topic")
+	}
+	if got := Kind(9).String(); got != "Kind(9)" {
+		t.Errorf("Kind(9).String() = %q, want %q", got, "Kind(9)")
+	}
+}
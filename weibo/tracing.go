@@ -0,0 +1,89 @@
+package weibo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SpanInfo describes an API request a span is started for.
+type SpanInfo struct {
+	// Method is the HTTP method of the request.
+	Method string
+
+	// Endpoint is the logical name of the API endpoint, e.g.
+	// "statuses/user_timeline", after which the span is named.
+	Endpoint string
+
+	// UID is the uid parameter of the request, if any.
+	UID string
+
+	// StatusID is the id parameter of requests to the statuses, comments
+	// and favorites endpoints, if any.
+	StatusID string
+}
+
+// A Tracer starts a span for each API request sent by Client.Do.  See the
+// weibootel package for an OpenTelemetry Tracer.
+type Tracer interface {
+	// StartSpan starts a span as a child of any span in ctx, and returns
+	// a context carrying it, with which the request is sent.
+	StartSpan(ctx context.Context, info *SpanInfo) (context.Context, Span)
+}
+
+// A Span is the trace of a single API request.
+type Span interface {
+	// End ends the span, once the request described by o completed.
+	End(o *Observation)
+}
+
+// startSpan starts a span for req with the Tracer of c, if any, and returns
+// req with the context of the span.
+func (c *Client) startSpan(req *http.Request) (*http.Request, Span) {
+	if c.Tracer == nil {
+		return req, nil
+	}
+
+	info := &SpanInfo{
+		Method:   req.Method,
+		Endpoint: Endpoint(req),
+	}
+	params := requestParams(req)
+	info.UID = params.Get("uid")
+	for _, prefix := range []string{"statuses/", "comments/", "favorites/"} {
+		if strings.HasPrefix(info.Endpoint, prefix) {
+			info.StatusID = params.Get("id")
+		}
+	}
+
+	ctx, span := c.Tracer.StartSpan(req.Context(), info)
+	return req.WithContext(ctx), span
+}
+
+// requestParams returns the URL query and form body parameters of req,
+// leaving its body unread.
+func requestParams(req *http.Request) url.Values {
+	params := req.URL.Query()
+	if req.GetBody == nil {
+		return params
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return params
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return params
+	}
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return params
+	}
+	for k, v := range form {
+		params[k] = append(params[k], v...)
+	}
+	return params
+}
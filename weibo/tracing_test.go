@@ -0,0 +1,80 @@
+package weibo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+type testTracer struct {
+	started []*SpanInfo
+	ended   []*Observation
+}
+
+type testSpanKey struct{}
+
+func (t *testTracer) StartSpan(ctx context.Context, info *SpanInfo) (context.Context, Span) {
+	t.started = append(t.started, info)
+	return context.WithValue(ctx, testSpanKey{}, info.Endpoint), testSpan{t}
+}
+
+type testSpan struct{ t *testTracer }
+
+func (s testSpan) End(o *Observation) {
+	s.t.ended = append(s.t.ended, o)
+}
+
+func TestClient_Tracer(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/repost.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":2}`)
+	})
+
+	tracer := new(testTracer)
+	client.Tracer = tracer
+
+	var spanEndpoint interface{}
+	client.Use(func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			spanEndpoint = req.Context().Value(testSpanKey{})
+			return next.Do(req)
+		})
+	})
+
+	if _, _, err := client.Statuses.Repost(&RepostRequest{ID: 1, Status: String("hi")}); err != nil {
+		t.Fatalf("Statuses.Repost returned error: %v", err)
+	}
+
+	want := []*SpanInfo{{Method: "POST", Endpoint: "statuses/repost", StatusID: "1"}}
+	if !reflect.DeepEqual(tracer.started, want) {
+		t.Errorf("started spans %+v, want %+v", tracer.started[0], want[0])
+	}
+	if len(tracer.ended) != 1 || tracer.ended[0].StatusCode != 200 || tracer.ended[0].Attempts != 1 {
+		t.Errorf("ended spans %+v, want one successful request", tracer.ended)
+	}
+	if spanEndpoint != "statuses/repost" {
+		t.Errorf("request context span = %v, want the started span", spanEndpoint)
+	}
+}
+
+func TestClient_Tracer_uid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/remind/unread_count.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status":1}`)
+	})
+
+	tracer := new(testTracer)
+	client.Tracer = tracer
+
+	client.Remind.UnreadCount("42")
+
+	if len(tracer.started) != 1 || tracer.started[0].UID != "42" || tracer.started[0].StatusID != "" {
+		t.Errorf("started spans %+v, want one with UID 42 and no StatusID", tracer.started)
+	}
+}
@@ -0,0 +1,95 @@
+package weibo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// UsersService handles communication with the user related
+// methods of the Weibo API.
+//
+// Weibo API docs: http://open.weibo.com/wiki/%E5%BE%AE%E5%8D%9AAPI
+type UsersService struct {
+	client *Client
+}
+
+// maxShowBatchUIDs is the maximum number of UIDs accepted by the
+// users/show_batch endpoint.
+const maxShowBatchUIDs = 50
+
+// userBatchOptions specifies the parameters to the UsersService.ShowBatch
+// method.
+type userBatchOptions struct {
+	UIDs []string `url:"uids,comma"`
+}
+
+// Users represents Weibo users set.
+type Users struct {
+	Users []User `json:"users,omitempty"`
+}
+
+// Show returns a single user.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/users/show
+func (s *UsersService) Show(uid string) (*User, *Response, error) {
+	u := fmt.Sprintf("users/show.json?uid=%v", uid)
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := new(User)
+	resp, err := s.client.Do(req, user)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return user, resp, err
+}
+
+// ShowBatch returns users by their UIDs, at most 50 at a time.
+//
+// Weibo API docs: http://open.weibo.com/wiki/2/users/show_batch
+func (s *UsersService) ShowBatch(uids []string) ([]User, *Response, error) {
+	u, err := addOptions("users/show_batch.json", &userBatchOptions{UIDs: uids})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	users := new(Users)
+	resp, err := s.client.Do(req, users)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return users.Users, resp, err
+}
+
+// ShowMany returns users by their numeric UIDs, keyed by UID.  The UIDs are
+// fetched from users/show_batch, with a few batches in flight at a time;
+// UIDs already being fetched by another ShowMany call are not fetched again,
+// but each call gets users of its own.  If some UIDs failed, the users found
+// are returned along with a *BatchError, which reports ErrNotFound for UIDs
+// of users which do not exist.
+func (s *UsersService) ShowMany(ctx context.Context, uids []int64) (map[int64]*User, error) {
+	return batchFetch[User](ctx, s.client, "users/show_batch", uids, maxShowBatchUIDs,
+		func(ctx context.Context, uids []int64) (map[int64]json.RawMessage, error) {
+			opt := &userBatchOptions{UIDs: make([]string, len(uids))}
+			for i, uid := range uids {
+				opt.UIDs[i] = strconv.FormatInt(uid, 10)
+			}
+			u, err := addOptions("users/show_batch.json", opt)
+			if err != nil {
+				return nil, err
+			}
+			return s.client.getBatch(ctx, u, "users")
+		})
+}
@@ -0,0 +1,74 @@
+package weibo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestUsersShow(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/users/show.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"uid": "1"})
+		fmt.Fprint(w, `{"id":1,"screen_name":"larrylv"}`)
+	})
+
+	user, _, err := client.Users.Show("1")
+	if err != nil {
+		t.Errorf("Users.Show returned error: %v", err)
+	}
+
+	want := &User{ID: Int(1), ScreeName: String("larrylv")}
+	if !reflect.DeepEqual(user, want) {
+		t.Errorf("Users.Show returned %+v, want %+v", user, want)
+	}
+}
+
+func TestUsersShowBatch(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/users/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testFormValues(t, r, values{"uids": "1,2"})
+		fmt.Fprint(w, `{"users":[{"id":1},{"id":2}]}`)
+	})
+
+	users, _, err := client.Users.ShowBatch([]string{"1", "2"})
+	if err != nil {
+		t.Errorf("Users.ShowBatch returned error: %v", err)
+	}
+
+	want := []User{{ID: Int(1)}, {ID: Int(2)}}
+	if !reflect.DeepEqual(users, want) {
+		t.Errorf("Users.ShowBatch returned %+v, want %+v", users, want)
+	}
+}
+
+func TestUsersShowMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/users/show_batch.json", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"uids": "1,2"})
+		fmt.Fprint(w, `{"users":[{"id":1}]}`)
+	})
+
+	users, err := client.Users.ShowMany(context.Background(), []int64{1, 2, 1})
+
+	if len(users) != 1 || users[1] == nil || *users[1].ID != 1 {
+		t.Errorf("Users.ShowMany returned %+v, want user 1", users)
+	}
+	batchErr, ok := err.(*BatchError)
+	if !ok {
+		t.Fatalf("Users.ShowMany returned error %v, want *BatchError", err)
+	}
+	if want := map[int64]error{2: ErrNotFound}; !reflect.DeepEqual(batchErr.Errors, want) {
+		t.Errorf("BatchError.Errors = %v, want %v", batchErr.Errors, want)
+	}
+}
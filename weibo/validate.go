@@ -0,0 +1,127 @@
+package weibo
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxStatusLength is the maximum length of a status as counted by
+	// TextLength.
+	maxStatusLength = 140
+
+	// urlUnits is the number of half-width units a URL counts for,
+	// regardless of its actual length.
+	urlUnits = 20
+)
+
+// urlPattern matches the URLs counted as a fixed length in status text.
+var urlPattern = regexp.MustCompile(`https?://[a-zA-Z0-9]+(\.[a-zA-Z0-9]+)+[-a-zA-Z0-9_$.+!*()/,:;@&=?~#%]*`)
+
+// TextLength returns the length of text as counted by Weibo: a Chinese (or
+// any other non-ASCII) character counts as one, two ASCII characters count as
+// one, and a URL counts as a fixed length of ten.
+func TextLength(text string) int {
+	units, last := 0, 0
+	for _, loc := range urlPattern.FindAllStringIndex(text, -1) {
+		units += textUnits(text[last:loc[0]]) + urlUnits
+		last = loc[1]
+	}
+	units += textUnits(text[last:])
+
+	return (units + 1) / 2
+}
+
+// textUnits returns the number of half-width units of s.
+func textUnits(s string) int {
+	n := 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			n++
+		} else {
+			n += 2
+		}
+	}
+	return n
+}
+
+// FieldError reports a problem with a single field of a request.
+type FieldError struct {
+	Field   string // name of the form field, e.g. "status"
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationError reports every problem found validating a request before
+// it is sent.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return "invalid request: " + strings.Join(msgs, "; ")
+}
+
+// add records a problem with field.
+func (e *ValidationError) add(field, format string, a ...interface{}) {
+	e.Errors = append(e.Errors, &FieldError{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// err returns e if any problem was recorded, nil otherwise.
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Validate checks r against the rules of the Weibo API, returning a
+// *ValidationError listing every problem found.
+func (r *StatusRequest) Validate() error {
+	e := new(ValidationError)
+	if r == nil {
+		e.add("status", "is required")
+		return e
+	}
+
+	if r.Status == nil || *r.Status == "" {
+		e.add("status", "is required")
+	} else if n := TextLength(*r.Status); n > maxStatusLength {
+		e.add("status", "is %d characters long, exceeding the limit of %d", n, maxStatusLength)
+	}
+
+	if r.Visible != nil && !r.Visible.Valid() {
+		e.add("visible", "must be one of public, private, friends or group, got %v", *r.Visible)
+	}
+	if r.Visible != nil && *r.Visible == VisibilityGroup && r.ListID == nil {
+		e.add("list_id", "is required when visible is group")
+	}
+	if r.ListID != nil && (r.Visible == nil || *r.Visible != VisibilityGroup) {
+		e.add("list_id", "requires visible to be group")
+	}
+
+	if r.Lat != nil && (*r.Lat < -90 || *r.Lat > 90) {
+		e.add("lat", "must be between -90 and 90, got %v", *r.Lat)
+	}
+	if r.Long != nil && (*r.Long < -180 || *r.Long > 180) {
+		e.add("long", "must be between -180 and 180, got %v", *r.Long)
+	}
+	if (r.Lat == nil) != (r.Long == nil) {
+		e.add("lat", "and long must be set together")
+	}
+
+	if err := r.Annotations.Validate(); err != nil {
+		e.add("annotations", "%v", err)
+	}
+
+	return e.err()
+}
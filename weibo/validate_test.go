@@ -0,0 +1,82 @@
+package weibo
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTextLength(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{"", 0},
+		{"a", 1},
+		{"ab", 1},
+		{"abc", 2},
+		{"你好", 2},
+		{"你好, weibo", 6},
+		{"http://t.cn/zOXAaic", 10},
+		{"看 http://example.com/a/very/long/path?with=query 吧", 13},
+		{strings.Repeat("微", 140), 140},
+	}
+
+	for _, tt := range tests {
+		if got := TextLength(tt.in); got != tt.want {
+			t.Errorf("TextLength(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestStatusRequest_Validate(t *testing.T) {
+	lat, long := 39.9, 116.3
+	badLat := 91.0
+	group, private, invalid := VisibilityGroup, VisibilityPrivate, Visibility(4)
+
+	tests := []struct {
+		req    *StatusRequest
+		fields []string
+	}{
+		{&StatusRequest{Status: String("hello")}, nil},
+		{&StatusRequest{Status: String("hello"), Visible: &group, ListID: Int64(1)}, nil},
+		{&StatusRequest{Status: String("hello"), Lat: &lat, Long: &long}, nil},
+		{nil, []string{"status"}},
+		{&StatusRequest{}, []string{"status"}},
+		{&StatusRequest{Status: String(strings.Repeat("微", 141))}, []string{"status"}},
+		{&StatusRequest{Status: String("hello"), Visible: &invalid}, []string{"visible"}},
+		{&StatusRequest{Status: String("hello"), Visible: &group}, []string{"list_id"}},
+		{&StatusRequest{Status: String("hello"), ListID: Int64(1)}, []string{"list_id"}},
+		{&StatusRequest{Status: String("hello"), Lat: &lat}, []string{"lat"}},
+		{&StatusRequest{Visible: &private, ListID: Int64(1), Lat: &badLat, Long: &long}, []string{"status", "list_id", "lat"}},
+	}
+
+	for _, tt := range tests {
+		err := tt.req.Validate()
+
+		var fields []string
+		if err != nil {
+			verr, ok := err.(*ValidationError)
+			if !ok {
+				t.Fatalf("Validate returned %#v, want *ValidationError", err)
+			}
+			for _, e := range verr.Errors {
+				fields = append(fields, e.Field)
+			}
+		}
+		if !reflect.DeepEqual(fields, tt.fields) {
+			t.Errorf("Validate(%+v) reported %v, want %v", tt.req, fields, tt.fields)
+		}
+	}
+}
+
+func TestStatusesCreate_invalid(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, _, err := client.Statuses.Create(&StatusRequest{})
+
+	if _, ok := err.(*ValidationError); !ok {
+		t.Errorf("Statuses.Create returned %#v, want *ValidationError", err)
+	}
+}
@@ -0,0 +1,105 @@
+package weibo
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Visibility is the visibility of a Weibo status.
+type Visibility int
+
+const (
+	VisibilityPublic  Visibility = 0 // visible to everyone
+	VisibilityPrivate Visibility = 1 // visible to the author only
+	VisibilityFriends Visibility = 2 // visible to mutual followers
+	VisibilityGroup   Visibility = 3 // visible to a friendship group
+)
+
+var visibilityNames = map[Visibility]string{
+	VisibilityPublic:  "public",
+	VisibilityPrivate: "private",
+	VisibilityFriends: "friends",
+	VisibilityGroup:   "group",
+}
+
+func (v Visibility) String() string {
+	if name, ok := visibilityNames[v]; ok {
+		return name
+	}
+	return "Visibility(" + strconv.Itoa(int(v)) + ")"
+}
+
+// Valid reports whether v is a visibility known to the Weibo API.
+func (v Visibility) Valid() bool {
+	_, ok := visibilityNames[v]
+	return ok
+}
+
+// MarshalJSON encodes v as a JSON number.
+func (v Visibility) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(v))), nil
+}
+
+// UnmarshalJSON decodes v from a JSON number, which may also be encoded as
+// a string.
+func (v *Visibility) UnmarshalJSON(data []byte) error {
+	n, err := unmarshalNumber(data)
+	if err != nil {
+		return err
+	}
+
+	*v = Visibility(n)
+	return nil
+}
+
+// EncodeValues implements query.Encoder, encoding v as a number.
+func (v Visibility) EncodeValues(key string, values *url.Values) error {
+	values.Set(key, strconv.Itoa(int(v)))
+	return nil
+}
+
+// newVisible returns a Visible of type v.
+func newVisible(v Visibility, listID *int64) *Visible {
+	return &Visible{VType: &v, ListID: listID}
+}
+
+// VisibleToPublic returns a Visible for statuses visible to everyone.
+func VisibleToPublic() *Visible {
+	return newVisible(VisibilityPublic, nil)
+}
+
+// VisibleToSelf returns a Visible for statuses visible to their author only.
+func VisibleToSelf() *Visible {
+	return newVisible(VisibilityPrivate, nil)
+}
+
+// VisibleToFriends returns a Visible for statuses visible to mutual
+// followers.
+func VisibleToFriends() *Visible {
+	return newVisible(VisibilityFriends, nil)
+}
+
+// VisibleToGroup returns a Visible for statuses visible to the friendship
+// group listID, see GroupsService.
+func VisibleToGroup(listID int64) *Visible {
+	return newVisible(VisibilityGroup, &listID)
+}
+
+// SetVisible sets the Visible and ListID fields of r from v, keeping them
+// consistent.  A nil v resets both, leaving the status public.  The list ID
+// is only copied for group visibility, as the Weibo API returns a zero list
+// ID for the other types.
+func (r *StatusRequest) SetVisible(v *Visible) {
+	r.Visible, r.ListID = nil, nil
+	if v == nil {
+		return
+	}
+	if v.VType != nil {
+		t := *v.VType
+		r.Visible = &t
+	}
+	if v.ListID != nil && r.Visible != nil && *r.Visible == VisibilityGroup {
+		id := *v.ListID
+		r.ListID = &id
+	}
+}
@@ -0,0 +1,114 @@
+package weibo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestVisibility_String(t *testing.T) {
+	tests := map[Visibility]string{
+		VisibilityPublic:  "public",
+		VisibilityPrivate: "private",
+		VisibilityFriends: "friends",
+		VisibilityGroup:   "group",
+		Visibility(7):     "Visibility(7)",
+	}
+
+	for v, want := range tests {
+		if got := v.String(); got != want {
+			t.Errorf("Visibility(%d).String() = %q, want %q", int(v), got, want)
+		}
+	}
+}
+
+func TestVisibility_JSON(t *testing.T) {
+	for _, in := range []string{`{"type": 3, "list_id": 42}`, `{"type": "3", "list_id": 42}`} {
+		var v Visible
+		if err := json.Unmarshal([]byte(in), &v); err != nil {
+			t.Errorf("json.Unmarshal(%v) returned error: %v", in, err)
+		}
+		if want := VisibleToGroup(42); !reflect.DeepEqual(&v, want) {
+			t.Errorf("json.Unmarshal(%v) = %+v, want %+v", in, v, want)
+		}
+	}
+
+	data, _ := json.Marshal(VisibleToFriends())
+	if want := `{"type":2}`; string(data) != want {
+		t.Errorf("json.Marshal returned %s, want %s", data, want)
+	}
+}
+
+func TestVisibility_EncodeValues(t *testing.T) {
+	v := url.Values{}
+	VisibilityFriends.EncodeValues("visible", &v)
+
+	if got := v.Get("visible"); got != "2" {
+		t.Errorf("EncodeValues encoded %q, want %q", got, "2")
+	}
+}
+
+func TestStatusRequest_SetVisible(t *testing.T) {
+	r := &StatusRequest{}
+
+	r.SetVisible(VisibleToGroup(42))
+	if *r.Visible != VisibilityGroup || *r.ListID != 42 {
+		t.Errorf("SetVisible(VisibleToGroup(42)) set %v, %v", *r.Visible, *r.ListID)
+	}
+
+	r.SetVisible(VisibleToFriends())
+	if *r.Visible != VisibilityFriends || r.ListID != nil {
+		t.Errorf("SetVisible(VisibleToFriends()) set %v, %v", *r.Visible, r.ListID)
+	}
+
+	r.SetVisible(nil)
+	if r.Visible != nil || r.ListID != nil {
+		t.Errorf("SetVisible(nil) set %v, %v", r.Visible, r.ListID)
+	}
+}
+
+func TestStatusRequest_SetVisible_decoded(t *testing.T) {
+	var status Status
+	if err := json.Unmarshal([]byte(`{"id": 1, "visible": {"type": 0, "list_id": 0}}`), &status); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+
+	r := &StatusRequest{Status: String("hello")}
+	r.SetVisible(status.Visible)
+	if *r.Visible != VisibilityPublic || r.ListID != nil {
+		t.Errorf("SetVisible(%+v) set %v, %v", status.Visible, *r.Visible, r.ListID)
+	}
+	if err := r.Validate(); err != nil {
+		t.Errorf("Validate returned error: %v", err)
+	}
+}
+
+func TestStatusesCreate_visibleToGroup(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/2/statuses/update.json", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testPostFormValues(t, r, values{
+			"status":  "hello",
+			"visible": "3",
+			"list_id": "3500000000000000",
+		})
+		fmt.Fprint(w, `{"id": 1, "visible": {"type": 3, "list_id": 3500000000000000}}`)
+	})
+
+	opt := &StatusRequest{Status: String("hello")}
+	opt.SetVisible(VisibleToGroup(3500000000000000))
+	status, _, err := client.Statuses.Create(opt)
+
+	if err != nil {
+		t.Errorf("Statuses.Create returned error: %v", err)
+	}
+
+	if want := VisibleToGroup(3500000000000000); !reflect.DeepEqual(status.Visible, want) {
+		t.Errorf("Statuses.Create returned visible %+v, want %+v", status.Visible, want)
+	}
+}
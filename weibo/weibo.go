@@ -9,6 +9,8 @@ import (
 	"net/url"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/go-querystring/query"
 )
@@ -28,14 +30,51 @@ type Client struct {
 	// Access Token
 	accessToken string
 
+	// Middleware wrapping client, see Use.
+	middleware []Middleware
+
+	// IDs being fetched by ShowMany methods, see batchFetch.
+	batchMu    sync.Mutex
+	batchCalls map[batchKey]*batchCall
+
 	// Base URL for API requests.
 	BaseURL *url.URL
 
 	// User agent used when communicating with the Weibo API.
 	UserAgent string
 
+	// Observer, if set, is notified of each request sent by Do.
+	Observer Observer
+
+	// Tracer, if set, starts a span for each request sent by Do.
+	Tracer Tracer
+
+	// Limiter, if set, enforces budgets on the requests sent by Do,
+	// including those retried by middleware.
+	Limiter *RateLimiter
+
+	// Cache, if set, stores the successful responses to GET requests sent
+	// by Do, for the TTL of their endpoint in EndpointCacheTTLs, or else
+	// for CacheTTL.  Responses are not cached if their TTL is not
+	// positive.  Other requests invalidate the cached responses of their
+	// resource, e.g. statuses, and of related ones.
+	Cache             Cache
+	CacheTTL          time.Duration
+	EndpointCacheTTLs map[string]time.Duration
+
 	// Services used for talking to different parts of the Weibo API.
-	Statuses *StatusesService
+	Statuses       *StatusesService
+	Remind         *RemindService
+	Tags           *TagsService
+	Groups         *GroupsService
+	Emotions       *EmotionsService
+	Location       *LocationService
+	Place          *PlaceService
+	Common         *CommonService
+	Suggestions    *SuggestionsService
+	DirectMessages *DirectMessagesService
+	Account        *AccountService
+	Users          *UsersService
 }
 
 // ListOptions specifies the optional parameters to various List methods that
@@ -76,10 +115,31 @@ func NewClient(accessToken string) *Client {
 
 	c := &Client{client: http.DefaultClient, accessToken: accessToken, BaseURL: baseURL, UserAgent: userAgent}
 	c.Statuses = &StatusesService{client: c}
+	c.Remind = &RemindService{client: c}
+	c.Tags = &TagsService{client: c}
+	c.Groups = &GroupsService{client: c}
+	c.Emotions = &EmotionsService{client: c}
+	c.Location = &LocationService{client: c}
+	c.Place = &PlaceService{client: c}
+	c.Common = &CommonService{client: c}
+	c.Suggestions = &SuggestionsService{client: c}
+	c.DirectMessages = &DirectMessagesService{client: c}
+	c.Account = &AccountService{client: c}
+	c.Users = &UsersService{client: c}
 
 	return c
 }
 
+// SetHTTPClient sets the HTTP client used to send API requests, e.g. one
+// with a custom http.RoundTripper.  A nil httpClient restores
+// http.DefaultClient.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	c.client = httpClient
+}
+
 // NewRequest creates an API request.  A relative URL can be provided in urlStr,
 // in which case it is resolved relative to the BaseURL of the Client.
 // Relative URLs should always be specified without a preceding slash. If
@@ -112,6 +172,7 @@ func (c *Client) NewRequest(method, urlString string, body interface{}) (*http.R
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(withRequestInfo(req.Context(), &RequestInfo{Endpoint: endpointName(rel.Path)}))
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; param=value")
 	req.Header.Add("User-Agent", c.UserAgent)
@@ -121,9 +182,12 @@ func (c *Client) NewRequest(method, urlString string, body interface{}) (*http.R
 
 // Response is a Weibo API response.
 // This wraps the standrad http.Response returned from Weibo.
-// For future use, maybe.
 type Response struct {
 	*http.Response
+
+	// FromCache is true if the response was read from the Cache of the
+	// Client, rather than sent by Weibo.
+	FromCache bool
 }
 
 // newResponse creates a new Response for the provided http.Response.
@@ -137,10 +201,33 @@ func newResponse(r *http.Response) *Response {
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occured.  If v implements the io.Writer
 // interface, the raw response body will be written to v, without attempting
-// to first decode it.
+// to first decode it.  The request is sent through the middleware chain of c,
+// see Use, unless its response is cached, see Client.Cache.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	if _, ok := RequestInfoFromContext(req.Context()); !ok {
+		// the context of req was replaced after NewRequest
+		req = req.WithContext(withRequestInfo(req.Context(), &RequestInfo{Endpoint: endpointName(req.URL.Path)}))
+	}
+
+	var cacheKey string
+	var cacheTTL time.Duration
+	if c.Cache != nil {
+		if req.Method != "GET" {
+			defer c.invalidateCache(Endpoint(req))
+		} else if cacheTTL = c.cacheTTL(Endpoint(req)); cacheTTL > 0 {
+			cacheKey = c.cacheKey(req)
+			if response, ok := c.cachedResponse(req, cacheKey); ok {
+				defer response.Body.Close()
+				return response, decodeResponse(response.Body, v)
+			}
+		}
+	}
+
+	start := time.Now()
+	req, span := c.startSpan(req)
+	resp, err := c.doer().Do(req)
 	if err != nil {
+		c.observe(req, span, start, nil, err)
 		return nil, err
 	}
 
@@ -149,20 +236,32 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	response := newResponse(resp)
 
 	if err := CheckResponse(resp); err != nil {
+		c.observe(req, span, start, resp, err)
 		// even though there was an error, we still return the response
 		// in case the caller wants to inspect it further
 		return response, err
 	}
+	c.observe(req, span, start, resp, nil)
 
-	if v != nil {
-		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
-		} else {
-			err = json.NewDecoder(resp.Body).Decode(v)
+	if cacheKey != "" {
+		if err := c.cacheResponse(cacheKey, resp, cacheTTL); err != nil {
+			return response, err
 		}
 	}
 
-	return response, err
+	return response, decodeResponse(resp.Body, v)
+}
+
+// decodeResponse decodes the body of a response into v, see Client.Do.
+func decodeResponse(body io.Reader, v interface{}) error {
+	if v == nil {
+		return nil
+	}
+	if w, ok := v.(io.Writer); ok {
+		io.Copy(w, body)
+		return nil
+	}
+	return json.NewDecoder(body).Decode(v)
 }
 
 // An Error Response reports one or more errors caused by an API request.
@@ -77,6 +77,21 @@ func TestNewClient(t *testing.T) {
 	}
 }
 
+func TestSetHTTPClient(t *testing.T) {
+	c := NewClient("123")
+
+	hc := &http.Client{}
+	c.SetHTTPClient(hc)
+	if c.client != hc {
+		t.Errorf("SetHTTPClient did not set the HTTP client")
+	}
+
+	c.SetHTTPClient(nil)
+	if c.client != http.DefaultClient {
+		t.Errorf("SetHTTPClient(nil) = %v, want http.DefaultClient", c.client)
+	}
+}
+
 func TestNewRequest(t *testing.T) {
 	c := NewClient("123")
 
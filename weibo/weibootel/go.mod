@@ -0,0 +1,19 @@
+module github.com/larrylv/go-weibo/weibo/weibootel
+
+go 1.21
+
+require (
+	github.com/larrylv/go-weibo v0.0.0-20261019132536-b9d64c7ee3ff
+	go.opentelemetry.io/otel v1.29.0
+	go.opentelemetry.io/otel/sdk v1.29.0
+	go.opentelemetry.io/otel/trace v1.29.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/go-querystring v1.1.0 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	go.opentelemetry.io/otel/metric v1.29.0 // indirect
+	golang.org/x/sys v0.24.0 // indirect
+)
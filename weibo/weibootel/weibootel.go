@@ -0,0 +1,104 @@
+// Package weibootel traces Weibo API requests with OpenTelemetry.
+//
+//	client := weibo.NewClient(token)
+//	client.Tracer = weibootel.NewTracer(nil)
+//
+// Each request is traced by a client span named after its endpoint, e.g.
+// "statuses/user_timeline", with the following attributes when known:
+//
+//	http.request.method
+//	http.response.status_code
+//	weibo.endpoint
+//	weibo.uid
+//	weibo.status_id
+//	weibo.error_code
+//	weibo.retry_count
+//
+// The span is in the context of the request, so an instrumented transport,
+// e.g. one from otelhttp set with Client.SetHTTPClient, traces the HTTP
+// requests as its children.
+//
+// weibootel is a module of its own, so that only its users depend on
+// OpenTelemetry.
+package weibootel
+
+import (
+	"context"
+
+	"github.com/larrylv/go-weibo/weibo"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package to tracer providers.
+const instrumentationName = "github.com/larrylv/go-weibo/weibo/weibootel"
+
+// Attribute keys of Weibo API request spans.
+const (
+	EndpointKey   = attribute.Key("weibo.endpoint")
+	UIDKey        = attribute.Key("weibo.uid")
+	StatusIDKey   = attribute.Key("weibo.status_id")
+	ErrorCodeKey  = attribute.Key("weibo.error_code")
+	RetryCountKey = attribute.Key("weibo.retry_count")
+
+	methodKey     = attribute.Key("http.request.method")
+	statusCodeKey = attribute.Key("http.response.status_code")
+)
+
+// Tracer is a weibo.Tracer which starts OpenTelemetry spans.
+type Tracer struct {
+	tracer trace.Tracer
+}
+
+// NewTracer returns a Tracer using tp, or the global tracer provider if tp
+// is nil.
+func NewTracer(tp trace.TracerProvider) *Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return &Tracer{tracer: tp.Tracer(instrumentationName)}
+}
+
+// StartSpan implements weibo.Tracer.
+func (t *Tracer) StartSpan(ctx context.Context, info *weibo.SpanInfo) (context.Context, weibo.Span) {
+	attrs := []attribute.KeyValue{
+		methodKey.String(info.Method),
+		EndpointKey.String(info.Endpoint),
+	}
+	if info.UID != "" {
+		attrs = append(attrs, UIDKey.String(info.UID))
+	}
+	if info.StatusID != "" {
+		attrs = append(attrs, StatusIDKey.String(info.StatusID))
+	}
+
+	ctx, s := t.tracer.Start(ctx, info.Endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return ctx, &span{span: s}
+}
+
+// span is a weibo.Span ending an OpenTelemetry span.
+type span struct {
+	span trace.Span
+}
+
+// End implements weibo.Span.
+func (s *span) End(o *weibo.Observation) {
+	if o.StatusCode != 0 {
+		s.span.SetAttributes(statusCodeKey.Int(o.StatusCode))
+	}
+	if o.ErrorCode != 0 {
+		s.span.SetAttributes(ErrorCodeKey.Int(o.ErrorCode))
+	}
+	if o.Attempts > 1 {
+		s.span.SetAttributes(RetryCountKey.Int(o.Attempts - 1))
+	}
+	if o.Err != nil {
+		s.span.RecordError(o.Err)
+		s.span.SetStatus(codes.Error, o.Err.Error())
+	}
+	s.span.End()
+}
@@ -0,0 +1,125 @@
+package weibootel
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo"
+	"github.com/larrylv/go-weibo/weibo/weibotest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup() (*weibotest.Server, *weibo.Client, *tracetest.SpanRecorder, string) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	server := weibotest.NewServer()
+	user, token := server.AddUser("alice")
+	client := server.Client(token)
+	client.Tracer = NewTracer(tp)
+	return server, client, recorder, strconv.Itoa(*user.ID)
+}
+
+func attributes(s sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracer(t *testing.T) {
+	server, client, recorder, uid := setup()
+	defer server.Close()
+
+	if _, _, err := client.Statuses.UserTimeline(&weibo.StatusListOptions{UID: uid}); err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	s := spans[0]
+	if s.Name() != "statuses/user_timeline" {
+		t.Errorf("span name = %q, want %q", s.Name(), "statuses/user_timeline")
+	}
+	if s.SpanKind() != trace.SpanKindClient {
+		t.Errorf("span kind = %v, want %v", s.SpanKind(), trace.SpanKindClient)
+	}
+	attrs := attributes(s)
+	if got := attrs[UIDKey].AsString(); got != uid {
+		t.Errorf("%s = %q, want %q", UIDKey, got, uid)
+	}
+	if got := attrs[statusCodeKey].AsInt64(); got != 200 {
+		t.Errorf("%s = %d, want 200", statusCodeKey, got)
+	}
+	if _, ok := attrs[ErrorCodeKey]; ok {
+		t.Errorf("%s set for a successful request", ErrorCodeKey)
+	}
+	if s.Status().Code == codes.Error {
+		t.Error("span status is Error for a successful request")
+	}
+}
+
+func TestTracer_error(t *testing.T) {
+	server, client, recorder, _ := setup()
+	defer server.Close()
+
+	if _, _, err := client.Statuses.ShowLongText(404); err == nil {
+		t.Fatal("Statuses.ShowLongText returned no error")
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	attrs := attributes(spans[0])
+	if got := attrs[StatusIDKey].AsString(); got != "404" {
+		t.Errorf("%s = %q, want %q", StatusIDKey, got, "404")
+	}
+	if got := attrs[ErrorCodeKey].AsInt64(); got != weibotest.ErrStatusNotExist {
+		t.Errorf("%s = %d, want %d", ErrorCodeKey, got, weibotest.ErrStatusNotExist)
+	}
+	if spans[0].Status().Code != codes.Error {
+		t.Errorf("span status = %v, want Error", spans[0].Status().Code)
+	}
+}
+
+func TestTracer_retries(t *testing.T) {
+	server, client, recorder, uid := setup()
+	defer server.Close()
+
+	client.Use(func(next weibo.Doer) weibo.Doer {
+		return weibo.DoerFunc(func(req *http.Request) (*http.Response, error) {
+			if resp, err := next.Do(req); err == nil {
+				resp.Body.Close()
+			}
+			return next.Do(req)
+		})
+	})
+
+	ctx, parent := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "parent")
+	req, _ := client.NewRequest("GET", "remind/unread_count.json?uid="+uid, nil)
+	if _, err := client.Do(req.WithContext(ctx), nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended %d spans, want 1", len(spans))
+	}
+	if got := attributes(spans[0])[RetryCountKey].AsInt64(); got != 1 {
+		t.Errorf("%s = %d, want 1", RetryCountKey, got)
+	}
+	if got, want := spans[0].Parent().SpanID(), parent.SpanContext().SpanID(); got != want {
+		t.Errorf("span parent = %v, want %v", got, want)
+	}
+}
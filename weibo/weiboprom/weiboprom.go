@@ -0,0 +1,106 @@
+// Package weiboprom exposes metrics of Weibo API requests as Prometheus
+// collectors.
+//
+//	observer, err := weiboprom.NewObserver(prometheus.DefaultRegisterer, nil)
+//	if err != nil {
+//		...
+//	}
+//	client := weibo.NewClient(token)
+//	client.Observer = observer
+//
+// The following metrics are exported, labeled by endpoint, e.g.
+// "statuses/user_timeline", and HTTP method:
+//
+//	weibo_requests_total{endpoint,method,status}
+//	weibo_request_duration_seconds{endpoint,method}
+//	weibo_errors_total{endpoint,method,error_code}
+//
+// weiboprom is a module of its own, so that only its users depend on the
+// Prometheus client.
+package weiboprom
+
+import (
+	"strconv"
+
+	"github.com/larrylv/go-weibo/weibo"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options specifies the optional parameters to NewObserver.
+type Options struct {
+	// Namespace of the metrics.  Defaults to "weibo".
+	Namespace string
+
+	// Buckets of the request duration histogram, in seconds.  Defaults to
+	// prometheus.DefBuckets.
+	Buckets []float64
+}
+
+// Observer is a weibo.Observer which records requests in Prometheus
+// collectors.
+type Observer struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewObserver returns an Observer whose collectors are registered on reg.  If
+// any of them fails to register, none is left registered.
+func NewObserver(reg prometheus.Registerer, opt *Options) (*Observer, error) {
+	if opt == nil {
+		opt = &Options{}
+	}
+	namespace := opt.Namespace
+	if namespace == "" {
+		namespace = "weibo"
+	}
+	buckets := opt.Buckets
+	if buckets == nil {
+		buckets = prometheus.DefBuckets
+	}
+
+	o := &Observer{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of Weibo API requests, by HTTP status code.",
+		}, []string{"endpoint", "method", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Latency of Weibo API requests.",
+			Buckets:   buckets,
+		}, []string{"endpoint", "method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of failed Weibo API requests, by Weibo error code.",
+		}, []string{"endpoint", "method", "error_code"}),
+	}
+
+	collectors := []prometheus.Collector{o.requests, o.duration, o.errors}
+	for i, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			// leave reg as it was
+			for _, registered := range collectors[:i] {
+				reg.Unregister(registered)
+			}
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// ObserveRequest records obs.  Requests which received no response are
+// counted with status "error".
+func (o *Observer) ObserveRequest(obs *weibo.Observation) {
+	status := "error"
+	if obs.StatusCode != 0 {
+		status = strconv.Itoa(obs.StatusCode)
+	}
+	o.requests.WithLabelValues(obs.Endpoint, obs.Method, status).Inc()
+	o.duration.WithLabelValues(obs.Endpoint, obs.Method).Observe(obs.Duration.Seconds())
+	if obs.ErrorCode != 0 {
+		o.errors.WithLabelValues(obs.Endpoint, obs.Method, strconv.Itoa(obs.ErrorCode)).Inc()
+	}
+}
@@ -0,0 +1,97 @@
+package weiboprom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/larrylv/go-weibo/weibo"
+	"github.com/larrylv/go-weibo/weibo/weibotest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestObserver(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	o, err := NewObserver(reg, nil)
+	if err != nil {
+		t.Fatalf("NewObserver returned error: %v", err)
+	}
+
+	server := weibotest.NewServer()
+	defer server.Close()
+	_, token := server.AddUser("alice")
+	client := server.Client(token)
+	client.Observer = o
+
+	if _, _, err := client.Statuses.UserTimeline(nil); err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+	if _, _, err := client.Statuses.ShowLongText(404); err == nil {
+		t.Fatal("Statuses.ShowLongText returned no error")
+	}
+
+	want := `
+# HELP weibo_requests_total Number of Weibo API requests, by HTTP status code.
+# TYPE weibo_requests_total counter
+weibo_requests_total{endpoint="statuses/show",method="GET",status="400"} 1
+weibo_requests_total{endpoint="statuses/user_timeline",method="GET",status="200"} 1
+# HELP weibo_errors_total Number of failed Weibo API requests, by Weibo error code.
+# TYPE weibo_errors_total counter
+weibo_errors_total{endpoint="statuses/show",error_code="20101",method="GET"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "weibo_requests_total", "weibo_errors_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(o.duration); n != 2 {
+		t.Errorf("collected %d duration series, want 2", n)
+	}
+}
+
+func TestObserver_noResponse(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	o, err := NewObserver(reg, &Options{Namespace: "test"})
+	if err != nil {
+		t.Fatalf("NewObserver returned error: %v", err)
+	}
+
+	o.ObserveRequest(&weibo.Observation{Method: "GET", Endpoint: "remind/unread_count", Duration: time.Second})
+
+	want := `
+# HELP test_requests_total Number of Weibo API requests, by HTTP status code.
+# TYPE test_requests_total counter
+test_requests_total{endpoint="remind/unread_count",method="GET",status="error"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(want), "test_requests_total"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestNewObserver_duplicate(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewObserver(reg, nil); err != nil {
+		t.Fatalf("NewObserver returned error: %v", err)
+	}
+	if _, err := NewObserver(reg, nil); err == nil {
+		t.Error("NewObserver registered duplicate collectors")
+	}
+}
+
+func TestNewObserver_partialRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	// a conflicting collector makes the last registration fail
+	reg.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "weibo_errors_total", Help: "conflict"}))
+
+	if _, err := NewObserver(reg, nil); err == nil {
+		t.Fatal("NewObserver returned no error")
+	}
+
+	requests := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "weibo",
+		Name:      "requests_total",
+		Help:      "Number of Weibo API requests, by HTTP status code.",
+	}, []string{"endpoint", "method", "status"})
+	if err := reg.Register(requests); err != nil {
+		t.Errorf("collector registered by the failed NewObserver was left registered: %v", err)
+	}
+}
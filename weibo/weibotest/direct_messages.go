@@ -0,0 +1,190 @@
+package weibotest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+// between reports whether m was sent by a to b or by b to a.
+func between(m *weibo.DirectMessage, a, b int64) bool {
+	return *m.SenderID == a && *m.RecipientID == b || *m.SenderID == b && *m.RecipientID == a
+}
+
+// ownMessage returns the message id sent or received by user.
+func (s *Server) ownMessage(user *weibo.User, id int64) (*weibo.DirectMessage, bool) {
+	m, ok := s.messages[id]
+	if !ok || *m.SenderID != userID(user) && *m.RecipientID != userID(user) {
+		return nil, false
+	}
+	return m, true
+}
+
+// messageList returns the page requested by form of the messages accepted
+// by keep, newest first and filtered by the since_id and max_id parameters
+// of form.
+func (s *Server) messageList(form url.Values, keep func(m *weibo.DirectMessage) bool) interface{} {
+	sinceID, _ := strconv.ParseInt(form.Get("since_id"), 10, 64)
+	maxID, _ := strconv.ParseInt(form.Get("max_id"), 10, 64)
+
+	messages := []*weibo.DirectMessage{}
+	for id, m := range s.messages {
+		if !keep(m) || id <= sinceID || maxID > 0 && id > maxID {
+			continue
+		}
+		messages = append(messages, m)
+	}
+	sort.Slice(messages, func(i, j int) bool { return *messages[i].ID > *messages[j].ID })
+
+	start, end := page(form, len(messages))
+	return map[string]interface{}{
+		"direct_messages": messages[start:end],
+		"total_number":    len(messages),
+		"previous_cursor": 0,
+		"next_cursor":     0,
+	}
+}
+
+func (s *Server) listMessages(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	return s.messageList(form, func(m *weibo.DirectMessage) bool {
+		return *m.RecipientID == userID(user)
+	}), nil
+}
+
+func (s *Server) sentMessages(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	return s.messageList(form, func(m *weibo.DirectMessage) bool {
+		return *m.SenderID == userID(user)
+	}), nil
+}
+
+func (s *Server) conversation(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.messageList(form, func(m *weibo.DirectMessage) bool {
+		return between(m, userID(user), userID(u))
+	}), nil
+}
+
+// messageContacts lists the users user exchanged messages with, along with
+// the latest message, the most recent conversation first.
+func (s *Server) messageContacts(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	latest := make(map[int64]*weibo.DirectMessage)
+	for _, m := range s.messages {
+		contact := *m.SenderID
+		if contact == userID(user) {
+			contact = *m.RecipientID
+		} else if *m.RecipientID != userID(user) {
+			continue
+		}
+		if l, ok := latest[contact]; !ok || *m.ID > *l.ID {
+			latest[contact] = m
+		}
+	}
+
+	contacts := make([]int64, 0, len(latest))
+	for id := range latest {
+		contacts = append(contacts, id)
+	}
+	sort.Slice(contacts, func(i, j int) bool { return *latest[contacts[i]].ID > *latest[contacts[j]].ID })
+
+	start, end, next, previous := cursorPage(form, len(contacts))
+	list := []map[string]interface{}{}
+	for _, id := range contacts[start:end] {
+		list = append(list, map[string]interface{}{"user": s.users[id], "direct_message": latest[id]})
+	}
+	return map[string]interface{}{
+		"user_list":       list,
+		"total_number":    len(contacts),
+		"next_cursor":     next,
+		"previous_cursor": previous,
+	}, nil
+}
+
+func (s *Server) showMessageBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	ids, err := int64List(form, "dmids")
+	if err != nil {
+		return nil, err
+	}
+
+	messages := []*weibo.DirectMessage{}
+	for _, id := range ids {
+		if m, ok := s.ownMessage(user, id); ok {
+			messages = append(messages, m)
+		}
+	}
+	return messages, nil
+}
+
+// isMessageCapable reports whether user can send messages to the user
+// identified by form, i.e. whether that user follows them, as on Weibo.
+func (s *Server) isMessageCapable(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]bool{"result": s.follows[userID(u)][userID(user)]}, nil
+}
+
+func (s *Server) createMessage(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	text := form.Get("text")
+	if text == "" {
+		return nil, paramError("miss required parameter (text)")
+	}
+	recipient, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	id := s.newID()
+	m := &weibo.DirectMessage{
+		ID:                  &id,
+		IDStr:               weibo.String(strconv.FormatInt(id, 10)),
+		MID:                 weibo.String(strconv.FormatInt(id, 10)),
+		CreatedAt:           weibo.String(s.now()),
+		Text:                weibo.String(text),
+		SenderID:            weibo.Int64(*user.ID),
+		RecipientID:         weibo.Int64(*recipient.ID),
+		SenderScreenName:    user.ScreeName,
+		RecipientScreenName: recipient.ScreeName,
+		Sender:              user,
+		Recipient:           recipient,
+	}
+	s.messages[id] = m
+	s.notify(recipient, "dm")
+
+	return m, nil
+}
+
+func (s *Server) destroyMessage(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	id, err := int64Param(form, "id")
+	if err != nil {
+		return nil, err
+	}
+	m, ok := s.ownMessage(user, id)
+	if !ok {
+		return nil, valueError("id")
+	}
+
+	delete(s.messages, id)
+	return m, nil
+}
+
+func (s *Server) destroyMessageBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	ids, err := int64List(form, "ids")
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		if _, ok := s.ownMessage(user, id); ok {
+			delete(s.messages, id)
+		}
+	}
+	return map[string]bool{"result": true}, nil
+}
@@ -0,0 +1,88 @@
+package weibotest
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+func TestServer_directMessages(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	alice, aliceToken := srv.AddUser("alice")
+	bob, bobToken := srv.AddUser("bob")
+	client := srv.Client(aliceToken)
+	bobClient := srv.Client(bobToken)
+	bobID := strconv.Itoa(*bob.ID)
+
+	if ok, _, _ := client.DirectMessages.IsCapable(bobID); ok {
+		t.Errorf("DirectMessages.IsCapable returned true for a user not following")
+	}
+	if err := post(t, bobClient, "friendships/create.json", &uidRequest{*alice.ID}, nil); err != nil {
+		t.Fatalf("friendships/create returned error: %v", err)
+	}
+	if ok, _, err := client.DirectMessages.IsCapable(bobID); err != nil || !ok {
+		t.Errorf("DirectMessages.IsCapable returned %v, %v, want true", ok, err)
+	}
+
+	sent, _, err := client.DirectMessages.Create(&weibo.DirectMessageRequest{Text: weibo.String("hi"), UID: &bobID})
+	if err != nil {
+		t.Fatalf("DirectMessages.Create returned error: %v", err)
+	}
+	if *sent.RecipientID != int64(*bob.ID) || *sent.Sender.ID != *alice.ID {
+		t.Errorf("DirectMessages.Create returned %+v", sent)
+	}
+	reply, _, _ := bobClient.DirectMessages.Create(&weibo.DirectMessageRequest{Text: weibo.String("hey"), ScreenName: weibo.String("alice")})
+
+	received, _, err := bobClient.DirectMessages.List(nil)
+	if err != nil {
+		t.Fatalf("DirectMessages.List returned error: %v", err)
+	}
+	if len(received.DirectMessages) != 1 || *received.DirectMessages[0].Text != "hi" {
+		t.Errorf("DirectMessages.List returned %+v", received)
+	}
+
+	conversation, _, err := client.DirectMessages.Conversation(bobID, nil)
+	if err != nil {
+		t.Fatalf("DirectMessages.Conversation returned error: %v", err)
+	}
+	if len(conversation.DirectMessages) != 2 || *conversation.DirectMessages[0].ID != *reply.ID {
+		t.Errorf("DirectMessages.Conversation returned %+v", conversation)
+	}
+
+	contacts, _, err := client.DirectMessages.UserList(nil)
+	if err != nil {
+		t.Fatalf("DirectMessages.UserList returned error: %v", err)
+	}
+	if len(contacts.UserList) != 1 || *contacts.UserList[0].DirectMessage.ID != *reply.ID {
+		t.Errorf("DirectMessages.UserList returned %+v", contacts)
+	}
+
+	count, _, _ := bobClient.Remind.UnreadCount(bobID)
+	if *count.DM != 1 {
+		t.Errorf("Remind.UnreadCount returned %v unread messages, want 1", *count.DM)
+	}
+
+	if _, _, err := client.DirectMessages.Destroy(*sent.ID); err != nil {
+		t.Errorf("DirectMessages.Destroy returned error: %v", err)
+	}
+	_, _, err = client.DirectMessages.Destroy(*sent.ID)
+	if code := errorCode(err); code != ErrParameterValue {
+		t.Errorf("DirectMessages.Destroy twice returned %v, want error code %d", err, ErrParameterValue)
+	}
+
+	if _, err := client.DirectMessages.DestroyBatch([]int64{*reply.ID}); err != nil {
+		t.Errorf("DirectMessages.DestroyBatch returned error: %v", err)
+	}
+	if messages, _, _ := client.DirectMessages.ShowBatch([]int64{*sent.ID, *reply.ID}); len(messages) != 0 {
+		t.Errorf("DirectMessages.ShowBatch returned %+v after destroying them", messages)
+	}
+
+	_, _, err = client.DirectMessages.Create(&weibo.DirectMessageRequest{Text: weibo.String("hi"), UID: weibo.String("1")})
+	if err, ok := err.(*weibo.ErrorResponse); !ok || err.ErrorCode != ErrUserNotExist || err.Response.StatusCode != http.StatusBadRequest {
+		t.Errorf("DirectMessages.Create to a missing user returned %v, want error code %d", err, ErrUserNotExist)
+	}
+}
@@ -0,0 +1,230 @@
+package weibotest
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+// group is a friendship group and the IDs of its members, in the order
+// they were added.
+type group struct {
+	*weibo.Group
+	members []int64
+}
+
+// memberIndex returns the index of the member uid of g, or -1.
+func (g *group) memberIndex(uid int64) int {
+	for i, id := range g.members {
+		if id == uid {
+			return i
+		}
+	}
+	return -1
+}
+
+// userGroups returns the groups of user ordered by ID, only those listing
+// uid if it is not zero.
+func (s *Server) userGroups(user *weibo.User, uid int64) []*weibo.Group {
+	groups := []*weibo.Group{}
+	for _, g := range s.groups {
+		if userID(g.User) == userID(user) && (uid == 0 || g.memberIndex(uid) >= 0) {
+			groups = append(groups, g.Group)
+		}
+	}
+	sort.Slice(groups, func(i, j int) bool { return *groups[i].ID < *groups[j].ID })
+	return groups
+}
+
+// ownGroup returns the group of user identified by the list_id parameter of
+// form.
+func (s *Server) ownGroup(user *weibo.User, form url.Values) (*group, *apiError) {
+	id, err := int64Param(form, "list_id")
+	if err != nil {
+		return nil, err
+	}
+	g, ok := s.groups[id]
+	if !ok || userID(g.User) != userID(user) {
+		return nil, valueError("list_id")
+	}
+	return g, nil
+}
+
+// setGroup sets the name, description and tags of g from form.
+func setGroup(g *weibo.Group, form url.Values) {
+	if name := form.Get("name"); name != "" {
+		g.Name = weibo.String(name)
+	}
+	if _, ok := form["description"]; ok {
+		g.Description = weibo.String(form.Get("description"))
+	}
+	if tags := form.Get("tags"); tags != "" {
+		g.Tags = strings.Split(tags, ",")
+	}
+}
+
+func (s *Server) listGroups(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	groups := s.userGroups(user, 0)
+	return map[string]interface{}{
+		"lists":        groups,
+		"total_number": len(groups),
+	}, nil
+}
+
+func (s *Server) groupTimeline(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return timelinePage(form, s.timeline(form, g.members...)), nil
+}
+
+func (s *Server) groupTimelineIDs(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+
+	return timelineIDs(form, s.timeline(form, g.members...)), nil
+}
+
+func (s *Server) groupMembers(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end, next, previous := cursorPage(form, len(g.members))
+	users := []*weibo.User{}
+	for _, id := range g.members[start:end] {
+		users = append(users, s.users[id])
+	}
+	return map[string]interface{}{
+		"users":           users,
+		"total_number":    len(g.members),
+		"next_cursor":     next,
+		"previous_cursor": previous,
+	}, nil
+}
+
+func (s *Server) isGroupMember(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	lists := []*weibo.Group{}
+	if g.memberIndex(userID(u)) >= 0 {
+		lists = append(lists, g.Group)
+	}
+	return map[string]interface{}{"lists": lists}, nil
+}
+
+func (s *Server) listedGroups(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	uids, err := int64List(form, "uids")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, uid := range uids {
+		if _, ok := s.users[uid]; ok {
+			result = append(result, map[string]interface{}{"uid": uid, "lists": s.userGroups(user, uid)})
+		}
+	}
+	return result, nil
+}
+
+func (s *Server) showGroup(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+	return g.Group, nil
+}
+
+func (s *Server) createGroup(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	if form.Get("name") == "" {
+		return nil, paramError("miss required parameter (name)")
+	}
+
+	id := s.newID()
+	g := &group{Group: &weibo.Group{
+		ID:          &id,
+		IDStr:       weibo.String(strconv.FormatInt(id, 10)),
+		Mode:        weibo.String("private"),
+		Visible:     weibo.Int(0),
+		LikeCount:   weibo.Int(0),
+		MemberCount: weibo.Int(0),
+		User:        user,
+		CreatedAt:   weibo.String(s.now()),
+	}}
+	setGroup(g.Group, form)
+	s.groups[id] = g
+
+	return g.Group, nil
+}
+
+func (s *Server) updateGroup(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+
+	setGroup(g.Group, form)
+	return g.Group, nil
+}
+
+func (s *Server) destroyGroup(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(s.groups, *g.ID)
+	return g.Group, nil
+}
+
+func (s *Server) addGroupMember(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.memberIndex(userID(u)) < 0 {
+		g.members = append(g.members, userID(u))
+		incr(g.MemberCount, 1)
+	}
+	return g.Group, nil
+}
+
+func (s *Server) removeGroupMember(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	g, err := s.ownGroup(user, form)
+	if err != nil {
+		return nil, err
+	}
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	i := g.memberIndex(userID(u))
+	if i < 0 {
+		return nil, valueError("uid")
+	}
+	g.members = append(g.members[:i:i], g.members[i+1:]...)
+	incr(g.MemberCount, -1)
+	return g.Group, nil
+}
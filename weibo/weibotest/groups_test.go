@@ -0,0 +1,87 @@
+package weibotest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+func TestServer_groups(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, token := srv.AddUser("alice")
+	bob, bobToken := srv.AddUser("bob")
+	_, carolToken := srv.AddUser("carol")
+	client := srv.Client(token)
+	bobID := strconv.Itoa(*bob.ID)
+
+	group, _, err := client.Groups.Create(&weibo.GroupRequest{Name: weibo.String("family")})
+	if err != nil {
+		t.Fatalf("Groups.Create returned error: %v", err)
+	}
+	if *group.Name != "family" || *group.MemberCount != 0 {
+		t.Errorf("Groups.Create returned %+v", group)
+	}
+
+	group, _, err = client.Groups.AddMember(*group.ID, bobID)
+	if err != nil {
+		t.Fatalf("Groups.AddMember returned error: %v", err)
+	}
+	if *group.MemberCount != 1 {
+		t.Errorf("Groups.AddMember returned %v members, want 1", *group.MemberCount)
+	}
+
+	srv.Client(bobToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello family")})
+	srv.Client(carolToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello world")})
+
+	timeline, _, err := client.Groups.Timeline(&weibo.GroupTimelineOptions{ListID: *group.ID})
+	if err != nil {
+		t.Fatalf("Groups.Timeline returned error: %v", err)
+	}
+	if len(timeline.Statuses) != 1 || *timeline.Statuses[0].Text != "hello family" {
+		t.Errorf("Groups.Timeline returned %+v", timeline.Statuses)
+	}
+
+	members, _, err := client.Groups.Members(&weibo.GroupMembersOptions{ListID: *group.ID})
+	if err != nil {
+		t.Fatalf("Groups.Members returned error: %v", err)
+	}
+	if len(members.Users) != 1 || *members.Users[0].ID != *bob.ID {
+		t.Errorf("Groups.Members returned %+v", members)
+	}
+
+	if ok, _, err := client.Groups.IsMember(*group.ID, bobID); err != nil || !ok {
+		t.Errorf("Groups.IsMember returned %v, %v, want true", ok, err)
+	}
+	listed, _, err := client.Groups.Listed([]string{bobID})
+	if err != nil || len(listed) != 1 || len(listed[0].Lists) != 1 {
+		t.Errorf("Groups.Listed returned %+v, %v", listed, err)
+	}
+
+	group, _, err = client.Groups.Update(*group.ID, &weibo.GroupRequest{Name: weibo.String("relatives")})
+	if err != nil || *group.Name != "relatives" {
+		t.Errorf("Groups.Update returned %+v, %v", group, err)
+	}
+
+	// groups are private to their owner
+	_, _, err = srv.Client(bobToken).Groups.Show(*group.ID)
+	if code := errorCode(err); code != ErrParameterValue {
+		t.Errorf("Groups.Show of another user returned %v, want error code %d", err, ErrParameterValue)
+	}
+
+	if _, _, err := client.Groups.RemoveMember(*group.ID, bobID); err != nil {
+		t.Errorf("Groups.RemoveMember returned error: %v", err)
+	}
+	if ok, _, _ := client.Groups.IsMember(*group.ID, bobID); ok {
+		t.Errorf("Groups.IsMember returned true after Groups.RemoveMember")
+	}
+
+	if _, _, err := client.Groups.Destroy(*group.ID); err != nil {
+		t.Errorf("Groups.Destroy returned error: %v", err)
+	}
+	if groups, _, _ := client.Groups.List(); len(groups) != 0 {
+		t.Errorf("Groups.List returned %+v after Groups.Destroy", groups)
+	}
+}
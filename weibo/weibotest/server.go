@@ -0,0 +1,942 @@
+// Package weibotest provides an in-memory fake of the Weibo API for testing
+// code built on the weibo package.
+//
+// A Server keeps users, statuses, comments, follows, favorites, tags,
+// friendship groups and direct messages in memory, authenticates requests by
+// their access token, checks their HTTP method and reports errors with the
+// error codes of the Weibo API.  It serves the endpoints of the statuses,
+// users, remind, tags, groups, direct messages and account services, and the
+// comments, friendships and favorites endpoints for use with
+// weibo.Client.NewRequest.  The endpoints of the emotions, location, place,
+// common and suggestions services only serve reference data of Weibo, which
+// a Server does not have; requests to them fail with HTTP 404:
+//
+//	srv := weibotest.NewServer()
+//	defer srv.Close()
+//
+//	user, token := srv.AddUser("larrylv")
+//	client := srv.Client(token)
+//	status, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")})
+package weibotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/larrylv/go-weibo/weibo"
+	weibotext "github.com/larrylv/go-weibo/weibo/text"
+)
+
+// Error codes of the Weibo API returned by the Server.
+//
+// Weibo API docs: http://open.weibo.com/wiki/Error_code
+const (
+	ErrMissingParameter = 10016
+	ErrParameterValue   = 10017
+	ErrMethodNotAllowed = 10021
+	ErrUserNotExist     = 20003
+	ErrTextTooLong      = 20012
+	ErrRepeatContent    = 20019
+	ErrStatusNotExist   = 20101
+	ErrNotYourStatus    = 20112
+	ErrFollowSelf       = 20504
+	ErrAlreadyFollowed  = 20506
+	ErrNotFollowed      = 20522
+	ErrAlreadyFavorited = 20704
+	ErrNotFavorited     = 20705
+	ErrInvalidToken     = 21332
+)
+
+const (
+	// defaultCount is the default page size of list endpoints.
+	defaultCount = 20
+
+	// createdAtLayout is the layout of the created_at fields.
+	createdAtLayout = time.RubyDate
+)
+
+// Comment represents a comment on a status.
+type Comment struct {
+	ID        int64         `json:"id"`
+	IDStr     string        `json:"idstr"`
+	CreatedAt string        `json:"created_at"`
+	Text      string        `json:"text"`
+	User      *weibo.User   `json:"user"`
+	Status    *weibo.Status `json:"status"`
+}
+
+// Favorite represents a status favorited by a user.
+type Favorite struct {
+	Status        *weibo.Status `json:"status"`
+	FavoritedTime string        `json:"favorited_time"`
+}
+
+// Server is a fake Weibo API server.
+type Server struct {
+	*httptest.Server
+
+	// Now returns the current time, used for the created_at fields.  It
+	// defaults to time.Now.
+	Now func() time.Time
+
+	mu        sync.Mutex
+	nextID    int64
+	users     map[int64]*weibo.User
+	tokens    map[string]int64
+	statuses  map[int64]*weibo.Status
+	comments  map[int64][]*Comment // keyed by status ID
+	follows   map[int64]map[int64]bool
+	favorites map[int64][]*Favorite    // keyed by user ID
+	unread    map[int64]map[string]int // keyed by user ID, then counter type
+	failures  map[string]*apiError     // keyed by endpoint
+	tags      map[int64][]*tag         // keyed by user ID
+	tagIDs    map[string]int64         // keyed by tag name
+	groups    map[int64]*group         // keyed by list ID
+	messages  map[int64]*weibo.DirectMessage
+}
+
+// apiError is the JSON error response of the Weibo API.
+type apiError struct {
+	status  int
+	Request string `json:"request"`
+	Code    int    `json:"error_code"`
+	Message string `json:"error"`
+}
+
+// NewServer starts and returns a new Server.  The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		Now:       time.Now,
+		nextID:    1000,
+		users:     make(map[int64]*weibo.User),
+		tokens:    make(map[string]int64),
+		statuses:  make(map[int64]*weibo.Status),
+		comments:  make(map[int64][]*Comment),
+		follows:   make(map[int64]map[int64]bool),
+		favorites: make(map[int64][]*Favorite),
+		unread:    make(map[int64]map[string]int),
+		failures:  make(map[string]*apiError),
+		tags:      make(map[int64][]*tag),
+		tagIDs:    make(map[string]int64),
+		groups:    make(map[int64]*group),
+		messages:  make(map[int64]*weibo.DirectMessage),
+	}
+
+	mux := http.NewServeMux()
+	for endpoint, r := range map[string]route{
+		"statuses/user_timeline":             {"GET", s.userTimeline},
+		"statuses/user_timeline/ids":         {"GET", s.userTimelineIDs},
+		"statuses/show":                      {"GET", s.showStatus},
+		"statuses/show_batch":                {"GET", s.showStatusBatch},
+		"statuses/update":                    {"POST", s.updateStatus},
+		"statuses/repost":                    {"POST", s.repostStatus},
+		"statuses/destroy":                   {"POST", s.destroyStatus},
+		"users/show":                         {"GET", s.showUser},
+		"users/show_batch":                   {"GET", s.showUserBatch},
+		"comments/show":                      {"GET", s.showComments},
+		"comments/create":                    {"POST", s.createComment},
+		"friendships/create":                 {"POST", s.createFriendship},
+		"friendships/destroy":                {"POST", s.destroyFriendship},
+		"friendships/friends":                {"GET", s.friends},
+		"friendships/followers":              {"GET", s.followers},
+		"favorites":                          {"GET", s.listFavorites},
+		"favorites/create":                   {"POST", s.createFavorite},
+		"favorites/destroy":                  {"POST", s.destroyFavorite},
+		"remind/unread_count":                {"GET", s.unreadCount},
+		"remind/set_count":                   {"POST", s.setCount},
+		"tags":                               {"GET", s.listTags},
+		"tags/tags_batch":                    {"GET", s.listTagsBatch},
+		"tags/suggestions":                   {"GET", s.tagSuggestions},
+		"tags/create":                        {"POST", s.createTags},
+		"tags/destroy":                       {"POST", s.destroyTag},
+		"tags/destroy_batch":                 {"POST", s.destroyTagBatch},
+		"friendships/groups":                 {"GET", s.listGroups},
+		"friendships/groups/timeline":        {"GET", s.groupTimeline},
+		"friendships/groups/timeline/ids":    {"GET", s.groupTimelineIDs},
+		"friendships/groups/members":         {"GET", s.groupMembers},
+		"friendships/groups/is_member":       {"GET", s.isGroupMember},
+		"friendships/groups/listed":          {"GET", s.listedGroups},
+		"friendships/groups/show":            {"GET", s.showGroup},
+		"friendships/groups/create":          {"POST", s.createGroup},
+		"friendships/groups/update":          {"POST", s.updateGroup},
+		"friendships/groups/destroy":         {"POST", s.destroyGroup},
+		"friendships/groups/members/add":     {"POST", s.addGroupMember},
+		"friendships/groups/members/destroy": {"POST", s.removeGroupMember},
+		"direct_messages":                    {"GET", s.listMessages},
+		"direct_messages/sent":               {"GET", s.sentMessages},
+		"direct_messages/conversation":       {"GET", s.conversation},
+		"direct_messages/user_list":          {"GET", s.messageContacts},
+		"direct_messages/show_batch":         {"GET", s.showMessageBatch},
+		"direct_messages/is_capable":         {"GET", s.isMessageCapable},
+		"direct_messages/new":                {"POST", s.createMessage},
+		"direct_messages/destroy":            {"POST", s.destroyMessage},
+		"direct_messages/destroy_batch":      {"POST", s.destroyMessageBatch},
+		"account/rate_limit_status":          {"GET", s.rateLimitStatus},
+	} {
+		mux.Handle("/2/"+endpoint+".json", s.wrap(endpoint, r))
+	}
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Client returns a weibo.Client talking to s with the given access token.
+func (s *Server) Client(token string) *weibo.Client {
+	c := weibo.NewClient(token)
+	c.BaseURL, _ = url.Parse(s.URL + "/")
+	return c
+}
+
+// AddUser adds a user with the given screen name and returns a copy of it
+// along with an access token authorized for it.
+func (s *Server) AddUser(screenName string) (*weibo.User, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.newID()
+	u := &weibo.User{
+		ID:              weibo.Int(int(id)),
+		ScreeName:       weibo.String(screenName),
+		Name:            weibo.String(screenName),
+		CreatedAt:       weibo.String(s.now()),
+		FollowersCount:  weibo.Int(0),
+		FriendsCount:    weibo.Int(0),
+		StatusesCount:   weibo.Int(0),
+		FavouritesCount: weibo.Int(0),
+	}
+	s.users[id] = u
+
+	token := fmt.Sprintf("token-%d", id)
+	s.tokens[token] = id
+
+	return clone(u), token
+}
+
+// Fail makes every subsequent request to endpoint, such as
+// "statuses/update", fail with the given HTTP status and Weibo error code,
+// until Recover is called.
+func (s *Server) Fail(endpoint string, status, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures[endpoint] = &apiError{status: status, Code: code, Message: message}
+}
+
+// Recover stops failing requests to endpoint.
+func (s *Server) Recover(endpoint string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.failures, endpoint)
+}
+
+// User returns a copy of the user with the given ID, or nil if it does not
+// exist.
+func (s *Server) User(id int64) *weibo.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return clone(s.users[id])
+}
+
+// Status returns a copy of the status with the given ID, or nil if it does
+// not exist.
+func (s *Server) Status(id int64) *weibo.Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return clone(s.statuses[id])
+}
+
+// clone returns a deep copy of v as clients decode it, so that callers do
+// not share the state guarded by s.mu.
+func clone[T any](v *T) *T {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	c := new(T)
+	if err := json.Unmarshal(data, c); err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// handler handles a request authenticated as user, returning the value to
+// encode as the JSON response, or an error.
+type handler func(user *weibo.User, form url.Values) (interface{}, *apiError)
+
+// route is an endpoint served with the given HTTP method by a handler.
+type route struct {
+	method  string
+	handler handler
+}
+
+// wrap returns an http.Handler authenticating requests to endpoint, and
+// serializing them with s.mu.
+func (s *Server) wrap(endpoint string, r route) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		req.ParseForm()
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		v, err := s.serve(endpoint, r, req)
+		w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+		if err != nil {
+			err.Request = req.URL.Path
+			w.WriteHeader(err.status)
+			v = err
+		}
+		json.NewEncoder(w).Encode(v)
+	})
+}
+
+func (s *Server) serve(endpoint string, r route, req *http.Request) (interface{}, *apiError) {
+	if req.Method != r.method {
+		return nil, codeError(ErrMethodNotAllowed, "HTTP method is not suported for this request!")
+	}
+
+	token := req.Form.Get("access_token")
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "OAuth2 ") {
+		token = strings.TrimPrefix(auth, "OAuth2 ")
+	}
+	uid, ok := s.tokens[token]
+	if !ok {
+		return nil, &apiError{status: http.StatusUnauthorized, Code: ErrInvalidToken, Message: "invalid_access_token"}
+	}
+
+	if err, ok := s.failures[endpoint]; ok {
+		e := *err
+		return nil, &e
+	}
+
+	return r.handler(s.users[uid], req.Form)
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+func (s *Server) now() string {
+	return s.Now().Format(createdAtLayout)
+}
+
+func paramError(format string, a ...interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: ErrMissingParameter, Message: fmt.Sprintf(format, a...)}
+}
+
+func codeError(code int, message string) *apiError {
+	return &apiError{status: http.StatusBadRequest, Code: code, Message: message}
+}
+
+func valueError(key string) *apiError {
+	return codeError(ErrParameterValue, fmt.Sprintf("parameter (%s)'s value invalid", key))
+}
+
+// int64Param returns the int64 parameter key of form.
+func int64Param(form url.Values, key string) (int64, *apiError) {
+	v := form.Get(key)
+	if v == "" {
+		return 0, paramError("miss required parameter (%s)", key)
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, paramError("invalid parameter (%s)", key)
+	}
+	return n, nil
+}
+
+// intParam returns the int parameter key of form, or def if it is not set.
+func intParam(form url.Values, key string, def int) int {
+	n, err := strconv.Atoi(form.Get(key))
+	if err != nil || n <= 0 {
+		return def
+	}
+	return n
+}
+
+// page returns the bounds of the page requested by form of n items.
+func page(form url.Values, n int) (int, int) {
+	count, p := intParam(form, "count", defaultCount), intParam(form, "page", 1)
+	start := (p - 1) * count
+	if start > n {
+		start = n
+	}
+	end := start + count
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// cursorPage returns the bounds of the page requested by the count and
+// cursor parameters of form of n items, and the cursors of the next and
+// previous pages.
+func cursorPage(form url.Values, n int) (start, end, next, previous int) {
+	count := intParam(form, "count", defaultCount)
+	start, _ = strconv.Atoi(form.Get("cursor"))
+	if start < 0 || start > n {
+		start = n
+	}
+	end = start + count
+	if end > n {
+		end = n
+	}
+	if end < n {
+		next = end
+	}
+	if previous = start - count; previous < 0 {
+		previous = 0
+	}
+	return start, end, next, previous
+}
+
+func userID(u *weibo.User) int64 {
+	return int64(*u.ID)
+}
+
+func incr(p *int, delta int) {
+	*p += delta
+}
+
+// targetUser returns the user identified by the uid or screen_name
+// parameters of form, or def if neither is set.
+func (s *Server) targetUser(form url.Values, def *weibo.User) (*weibo.User, *apiError) {
+	if uid := form.Get("uid"); uid != "" {
+		id, err := strconv.ParseInt(uid, 10, 64)
+		if u, ok := s.users[id]; err == nil && ok {
+			return u, nil
+		}
+		return nil, codeError(ErrUserNotExist, "User does not exists!")
+	}
+	if name := form.Get("screen_name"); name != "" {
+		for _, u := range s.users {
+			if *u.ScreeName == name {
+				return u, nil
+			}
+		}
+		return nil, codeError(ErrUserNotExist, "User does not exists!")
+	}
+	if def == nil {
+		return nil, paramError("miss required parameter (uid), see doc for more info.")
+	}
+	return def, nil
+}
+
+// status returns the status identified by the id parameter of form.
+func (s *Server) status(form url.Values) (*weibo.Status, *apiError) {
+	id, err := int64Param(form, "id")
+	if err != nil {
+		return nil, err
+	}
+	status, ok := s.statuses[id]
+	if !ok {
+		return nil, codeError(ErrStatusNotExist, "target weibo does not exist!")
+	}
+	return status, nil
+}
+
+// timeline returns the statuses of the users uids newest first, filtered by
+// the since_id and max_id parameters of form.
+func (s *Server) timeline(form url.Values, uids ...int64) []*weibo.Status {
+	sinceID, _ := strconv.ParseInt(form.Get("since_id"), 10, 64)
+	maxID, _ := strconv.ParseInt(form.Get("max_id"), 10, 64)
+
+	authors := make(map[int64]bool, len(uids))
+	for _, uid := range uids {
+		authors[uid] = true
+	}
+
+	var statuses []*weibo.Status
+	for id, status := range s.statuses {
+		if !authors[userID(status.User)] || id <= sinceID || maxID > 0 && id > maxID {
+			continue
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return *statuses[i].ID > *statuses[j].ID })
+
+	return statuses
+}
+
+func (s *Server) userTimeline(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return timelinePage(form, s.timeline(form, userID(u))), nil
+}
+
+// timelinePage returns the page of statuses requested by form.
+func timelinePage(form url.Values, statuses []*weibo.Status) interface{} {
+	start, end := page(form, len(statuses))
+	return map[string]interface{}{
+		"statuses":     statuses[start:end],
+		"total_number": len(statuses),
+	}
+}
+
+func (s *Server) userTimelineIDs(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return timelineIDs(form, s.timeline(form, userID(u))), nil
+}
+
+// timelineIDs returns the page of status IDs requested by form.
+func timelineIDs(form url.Values, statuses []*weibo.Status) interface{} {
+	start, end := page(form, len(statuses))
+	ids := []string{}
+	for _, status := range statuses[start:end] {
+		ids = append(ids, *status.IDStr)
+	}
+	return map[string]interface{}{
+		"statuses":     ids,
+		"total_number": len(statuses),
+	}
+}
+
+func (s *Server) showStatus(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	return s.status(form)
+}
+
+func (s *Server) showStatusBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	if form.Get("ids") == "" {
+		return nil, paramError("miss required parameter (ids)")
+	}
+
+	statuses := []*weibo.Status{}
+	for _, v := range strings.Split(form.Get("ids"), ",") {
+		id, _ := strconv.ParseInt(v, 10, 64)
+		if status, ok := s.statuses[id]; ok {
+			statuses = append(statuses, status)
+		}
+	}
+	return map[string]interface{}{"statuses": statuses}, nil
+}
+
+// newStatus adds a status of user with the given text, reposting original
+// if it is not nil.  Only original statuses are checked for repeated
+// content, as reposts commonly share the default text.
+func (s *Server) newStatus(user *weibo.User, text string, original *weibo.Status) (*weibo.Status, *apiError) {
+	if text == "" {
+		return nil, paramError("miss required parameter (status)")
+	}
+	if weibo.TextLength(text) > 140 {
+		return nil, codeError(ErrTextTooLong, "Text too long, please input text less than 140 characters!")
+	}
+	if original == nil {
+		for _, status := range s.timeline(url.Values{}, userID(user)) {
+			if *status.Text == text {
+				return nil, codeError(ErrRepeatContent, "repeat content!")
+			}
+		}
+	}
+
+	id := s.newID()
+	status := &weibo.Status{
+		ID:              &id,
+		IDStr:           weibo.String(strconv.FormatInt(id, 10)),
+		MID:             weibo.String(strconv.FormatInt(id, 10)),
+		CreatedAt:       weibo.String(s.now()),
+		Text:            weibo.String(text),
+		User:            user,
+		Favorited:       weibo.Bool(false),
+		Truncated:       weibo.Bool(false),
+		RepostsCount:    weibo.Int(0),
+		CommentsCount:   weibo.Int(0),
+		AttitudesCount:  weibo.Int(0),
+		Visible:         weibo.VisibleToPublic(),
+		RetweetedStatus: original,
+	}
+	s.statuses[id] = status
+	incr(user.StatusesCount, 1)
+	if original != nil {
+		incr(original.RepostsCount, 1)
+	}
+	for follower, following := range s.follows {
+		if following[userID(user)] {
+			s.notify(s.users[follower], "status")
+		}
+	}
+	s.notifyMentions(text, "mention_status")
+
+	return status, nil
+}
+
+func (s *Server) updateStatus(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.newStatus(user, form.Get("status"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if v := form.Get("visible"); v != "" {
+		n, _ := strconv.Atoi(v)
+		status.Visible = &weibo.Visible{VType: new(weibo.Visibility)}
+		*status.Visible.VType = weibo.Visibility(n)
+		if listID := form.Get("list_id"); listID != "" {
+			id, _ := strconv.ParseInt(listID, 10, 64)
+			status.Visible.ListID = &id
+		}
+	}
+	if a := form.Get("annotations"); a != "" {
+		json.Unmarshal([]byte(a), &status.Annotations)
+	}
+
+	return status, nil
+}
+
+func (s *Server) repostStatus(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	original, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+	if original.RetweetedStatus != nil {
+		original = original.RetweetedStatus
+	}
+
+	text := form.Get("status")
+	if text == "" {
+		text = "转发微博"
+	}
+	status, err := s.newStatus(user, text, original)
+	if err != nil {
+		return nil, err
+	}
+
+	if form.Get("is_comment") == "1" || form.Get("is_comment") == "3" {
+		s.addComment(user, original, text)
+	}
+
+	return status, nil
+}
+
+func (s *Server) destroyStatus(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+	if *status.User.ID != *user.ID {
+		return nil, codeError(ErrNotYourStatus, "You can't delete the weibo of others!")
+	}
+
+	delete(s.statuses, *status.ID)
+	delete(s.comments, *status.ID)
+	incr(user.StatusesCount, -1)
+	if status.RetweetedStatus != nil {
+		incr(status.RetweetedStatus.RepostsCount, -1)
+	}
+	for uid, favorites := range s.favorites {
+		kept := favorites[:0]
+		for _, f := range favorites {
+			if f.Status == status {
+				incr(s.users[uid].FavouritesCount, -1)
+				continue
+			}
+			kept = append(kept, f)
+		}
+		s.favorites[uid] = kept
+	}
+
+	return status, nil
+}
+
+func (s *Server) showUser(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	return s.targetUser(form, nil)
+}
+
+func (s *Server) showUserBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	if form.Get("uids") == "" {
+		return nil, paramError("miss required parameter (uids)")
+	}
+
+	users := []*weibo.User{}
+	for _, v := range strings.Split(form.Get("uids"), ",") {
+		id, _ := strconv.ParseInt(v, 10, 64)
+		if u, ok := s.users[id]; ok {
+			users = append(users, u)
+		}
+	}
+	return map[string]interface{}{"users": users}, nil
+}
+
+func (s *Server) addComment(user *weibo.User, status *weibo.Status, text string) *Comment {
+	id := s.newID()
+	c := &Comment{
+		ID:        id,
+		IDStr:     strconv.FormatInt(id, 10),
+		CreatedAt: s.now(),
+		Text:      text,
+		User:      user,
+		Status:    status,
+	}
+	s.comments[*status.ID] = append(s.comments[*status.ID], c)
+	incr(status.CommentsCount, 1)
+	if status.User != user {
+		s.notify(status.User, "cmt")
+	}
+	s.notifyMentions(text, "mention_cmt")
+
+	return c
+}
+
+func (s *Server) showComments(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+
+	// newest first
+	all := s.comments[*status.ID]
+	comments := make([]*Comment, len(all))
+	for i, c := range all {
+		comments[len(all)-1-i] = c
+	}
+
+	start, end := page(form, len(comments))
+	return map[string]interface{}{
+		"comments":     comments[start:end],
+		"total_number": len(comments),
+	}, nil
+}
+
+func (s *Server) createComment(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+
+	text := form.Get("comment")
+	if text == "" {
+		return nil, paramError("miss required parameter (comment)")
+	}
+
+	return s.addComment(user, status, text), nil
+}
+
+func (s *Server) createFriendship(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	target, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+	if target == user {
+		return nil, codeError(ErrFollowSelf, "can not follow yourself!")
+	}
+
+	following := s.follows[userID(user)]
+	if following == nil {
+		following = make(map[int64]bool)
+		s.follows[userID(user)] = following
+	}
+	if following[userID(target)] {
+		return nil, codeError(ErrAlreadyFollowed, "already followed")
+	}
+
+	following[userID(target)] = true
+	incr(user.FriendsCount, 1)
+	incr(target.FollowersCount, 1)
+	s.notify(target, "follower")
+
+	return target, nil
+}
+
+func (s *Server) destroyFriendship(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	target, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !s.follows[userID(user)][userID(target)] {
+		return nil, codeError(ErrNotFollowed, "not followed")
+	}
+
+	delete(s.follows[userID(user)], userID(target))
+	incr(user.FriendsCount, -1)
+	incr(target.FollowersCount, -1)
+
+	return target, nil
+}
+
+// userList returns the page of users requested by form, ordered by ID.
+func (s *Server) userList(form url.Values, ids []int64) interface{} {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	users := []*weibo.User{}
+	start, end := page(form, len(ids))
+	for _, id := range ids[start:end] {
+		users = append(users, s.users[id])
+	}
+	return map[string]interface{}{
+		"users":        users,
+		"total_number": len(ids),
+	}
+}
+
+func (s *Server) friends(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for id := range s.follows[userID(u)] {
+		ids = append(ids, id)
+	}
+	return s.userList(form, ids), nil
+}
+
+func (s *Server) followers(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for id, following := range s.follows {
+		if following[userID(u)] {
+			ids = append(ids, id)
+		}
+	}
+	return s.userList(form, ids), nil
+}
+
+func (s *Server) listFavorites(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	all := s.favorites[userID(user)]
+	favorites := make([]*Favorite, len(all))
+	for i, f := range all {
+		favorites[len(all)-1-i] = f
+	}
+
+	start, end := page(form, len(favorites))
+	return map[string]interface{}{
+		"favorites":    favorites[start:end],
+		"total_number": len(favorites),
+	}, nil
+}
+
+// favoriteIndex returns the index of the favorite of status by user, or -1.
+func (s *Server) favoriteIndex(user *weibo.User, status *weibo.Status) int {
+	for i, f := range s.favorites[userID(user)] {
+		if f.Status == status {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) createFavorite(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+	if s.favoriteIndex(user, status) >= 0 {
+		return nil, codeError(ErrAlreadyFavorited, "already favorited")
+	}
+
+	f := &Favorite{Status: status, FavoritedTime: s.now()}
+	s.favorites[userID(user)] = append(s.favorites[userID(user)], f)
+	incr(user.FavouritesCount, 1)
+
+	return f, nil
+}
+
+func (s *Server) destroyFavorite(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	status, err := s.status(form)
+	if err != nil {
+		return nil, err
+	}
+	i := s.favoriteIndex(user, status)
+	if i < 0 {
+		return nil, codeError(ErrNotFavorited, "not favorited")
+	}
+
+	favorites := s.favorites[userID(user)]
+	f := favorites[i]
+	s.favorites[userID(user)] = append(favorites[:i], favorites[i+1:]...)
+	incr(user.FavouritesCount, -1)
+
+	return f, nil
+}
+
+// notify increments the unread counter of the given type for u.
+func (s *Server) notify(u *weibo.User, counter string) {
+	unread := s.unread[userID(u)]
+	if unread == nil {
+		unread = make(map[string]int)
+		s.unread[userID(u)] = unread
+	}
+	unread[counter]++
+}
+
+// notifyMentions increments the mention counter of the given type for the
+// users mentioned in text.
+func (s *Server) notifyMentions(text, counter string) {
+	for _, e := range weibotext.Extract(text) {
+		if e.Kind != weibotext.Mention {
+			continue
+		}
+		if u, err := s.targetUser(url.Values{"screen_name": {e.Value}}, nil); err == nil {
+			s.notify(u, counter)
+		}
+	}
+}
+
+func (s *Server) unreadCount(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	unread := s.unread[userID(u)]
+	return &weibo.UnreadCount{
+		Status:        weibo.Int(unread["status"]),
+		Follower:      weibo.Int(unread["follower"]),
+		Cmt:           weibo.Int(unread["cmt"]),
+		DM:            weibo.Int(unread["dm"]),
+		MentionStatus: weibo.Int(unread["mention_status"]),
+		MentionCmt:    weibo.Int(unread["mention_cmt"]),
+	}, nil
+}
+
+func (s *Server) setCount(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	counter := form.Get("type")
+	if counter == "" {
+		return nil, paramError("miss required parameter (type)")
+	}
+
+	delete(s.unread[userID(user)], counter)
+	return map[string]bool{"result": true}, nil
+}
+
+// rateLimitStatus reports the full default rate limits of Weibo, as a Server
+// does not limit requests, except those made to fail with Fail.
+func (s *Server) rateLimitStatus(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	now := s.Now()
+	reset := now.Truncate(time.Hour).Add(time.Hour)
+	return &weibo.RateLimitStatus{
+		APIRateLimits:      []weibo.APIRateLimit{},
+		IPLimit:            weibo.Int(10000),
+		LimitTimeUnit:      weibo.String("HOURS"),
+		RemainingIPHits:    weibo.Int(10000),
+		UserLimit:          weibo.Int(150),
+		RemainingUserHits:  weibo.Int(150),
+		ResetTime:          weibo.String(reset.Format("2006-01-02 15:04:05")),
+		ResetTimeInSeconds: weibo.Int(int(reset.Sub(now).Seconds())),
+	}, nil
+}
@@ -0,0 +1,360 @@
+package weibotest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+// post sends a POST request to endpoint with body through client.
+func post(t *testing.T, client *weibo.Client, endpoint string, body interface{}, v interface{}) error {
+	req, err := client.NewRequest("POST", endpoint, body)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	_, err = client.Do(req, v)
+	return err
+}
+
+func errorCode(err error) int {
+	if err, ok := err.(*weibo.ErrorResponse); ok {
+		return err.ErrorCode
+	}
+	return 0
+}
+
+func TestServer_auth(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, _, err := srv.Client("bad").Statuses.UserTimeline(nil)
+
+	if code := errorCode(err); code != ErrInvalidToken {
+		t.Errorf("UserTimeline with bad token returned %v, want error code %d", err, ErrInvalidToken)
+	}
+	if err, ok := err.(*weibo.ErrorResponse); !ok || err.Response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("UserTimeline with bad token returned %v, want HTTP 401", err)
+	}
+}
+
+func TestServer_statuses(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	user, token := srv.AddUser("larrylv")
+	client := srv.Client(token)
+
+	var ids []int64
+	for _, text := range []string{"one", "two", "three"} {
+		status, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String(text)})
+		if err != nil {
+			t.Fatalf("Statuses.Create returned error: %v", err)
+		}
+		if *status.User.ID != *user.ID {
+			t.Errorf("Statuses.Create returned user %v, want %v", *status.User.ID, *user.ID)
+		}
+		ids = append(ids, *status.ID)
+	}
+
+	_, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("one")})
+	if code := errorCode(err); code != ErrRepeatContent {
+		t.Errorf("Statuses.Create repeated returned %v, want error code %d", err, ErrRepeatContent)
+	}
+
+	timeline, _, err := client.Statuses.UserTimeline(&weibo.StatusListOptions{ListOptions: weibo.ListOptions{PerPage: 2}})
+	if err != nil {
+		t.Fatalf("Statuses.UserTimeline returned error: %v", err)
+	}
+	if len(timeline.Statuses) != 2 || *timeline.Statuses[0].Text != "three" || *timeline.TotalNumber != 3 {
+		t.Errorf("Statuses.UserTimeline returned %+v", timeline)
+	}
+
+	timelineIDs, _, err := client.Statuses.UserTimelineIDs(&weibo.StatusListOptions{SinceID: "0", ScreenName: "larrylv"})
+	if err != nil {
+		t.Fatalf("Statuses.UserTimelineIDs returned error: %v", err)
+	}
+	if len(timelineIDs.StatusesIDs) != 3 {
+		t.Errorf("Statuses.UserTimelineIDs returned %+v", timelineIDs)
+	}
+
+	statuses, _, err := client.Statuses.ShowBatch([]int64{ids[0], ids[2], 1}, false)
+	if err != nil {
+		t.Fatalf("Statuses.ShowBatch returned error: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Errorf("Statuses.ShowBatch returned %d statuses, want 2", len(statuses))
+	}
+
+	_, _, err = client.Statuses.ShowLongText(1)
+	if code := errorCode(err); code != ErrStatusNotExist {
+		t.Errorf("Statuses.ShowLongText returned %v, want error code %d", err, ErrStatusNotExist)
+	}
+}
+
+func TestServer_repostAndComments(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	author, authorToken := srv.AddUser("author")
+	_, readerToken := srv.AddUser("reader")
+	reader := srv.Client(readerToken)
+
+	original, _, _ := srv.Client(authorToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("original")})
+
+	repost, _, err := reader.Statuses.Repost(&weibo.RepostRequest{ID: *original.ID, Status: weibo.String("nice @author"), IsComment: weibo.Int(1)})
+	if err != nil {
+		t.Fatalf("Statuses.Repost returned error: %v", err)
+	}
+	if *repost.RetweetedStatus.ID != *original.ID {
+		t.Errorf("Statuses.Repost returned %+v, want repost of %v", repost, *original.ID)
+	}
+
+	var comments struct {
+		Comments    []Comment `json:"comments"`
+		TotalNumber int       `json:"total_number"`
+	}
+	req, _ := reader.NewRequest("GET", "comments/show.json?id="+*original.IDStr, nil)
+	if _, err := reader.Do(req, &comments); err != nil {
+		t.Fatalf("comments/show returned error: %v", err)
+	}
+	if comments.TotalNumber != 1 || comments.Comments[0].Text != "nice @author" {
+		t.Errorf("comments/show returned %+v", comments)
+	}
+
+	got := srv.Status(*original.ID)
+	if *got.RepostsCount != 1 || *got.CommentsCount != 1 {
+		t.Errorf("original status counts = %v reposts, %v comments, want 1, 1", *got.RepostsCount, *got.CommentsCount)
+	}
+
+	count, _, err := srv.Client(authorToken).Remind.UnreadCount(strconv.Itoa(*author.ID))
+	if err != nil {
+		t.Fatalf("Remind.UnreadCount returned error: %v", err)
+	}
+	if *count.Cmt != 1 || *count.MentionStatus != 1 {
+		t.Errorf("Remind.UnreadCount returned %+v, want 1 cmt and 1 mention_status", count)
+	}
+}
+
+type uidRequest struct {
+	UID int `url:"uid"`
+}
+
+type idRequest struct {
+	ID int64 `url:"id"`
+}
+
+func TestServer_friendships(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	alice, token := srv.AddUser("alice")
+	bob, _ := srv.AddUser("bob")
+	client := srv.Client(token)
+
+	followed := new(weibo.User)
+	if err := post(t, client, "friendships/create.json", &uidRequest{*bob.ID}, followed); err != nil {
+		t.Fatalf("friendships/create returned error: %v", err)
+	}
+	if !reflect.DeepEqual(followed.ID, bob.ID) || *followed.FollowersCount != 1 || *srv.User(int64(*alice.ID)).FriendsCount != 1 {
+		t.Errorf("friendships/create returned %+v", followed)
+	}
+
+	err := post(t, client, "friendships/create.json", &uidRequest{*bob.ID}, nil)
+	if code := errorCode(err); code != ErrAlreadyFollowed {
+		t.Errorf("friendships/create twice returned %v, want error code %d", err, ErrAlreadyFollowed)
+	}
+	err = post(t, client, "friendships/create.json", &uidRequest{*alice.ID}, nil)
+	if code := errorCode(err); code != ErrFollowSelf {
+		t.Errorf("friendships/create self returned %v, want error code %d", err, ErrFollowSelf)
+	}
+
+	if err := post(t, client, "friendships/destroy.json", &uidRequest{*bob.ID}, nil); err != nil {
+		t.Errorf("friendships/destroy returned error: %v", err)
+	}
+	err = post(t, client, "friendships/destroy.json", &uidRequest{*bob.ID}, nil)
+	if code := errorCode(err); code != ErrNotFollowed {
+		t.Errorf("friendships/destroy twice returned %v, want error code %d", err, ErrNotFollowed)
+	}
+}
+
+func TestServer_favorites(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	user, token := srv.AddUser("larrylv")
+	client := srv.Client(token)
+	status, _, _ := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")})
+
+	favorite := new(Favorite)
+	if err := post(t, client, "favorites/create.json", &idRequest{*status.ID}, favorite); err != nil {
+		t.Fatalf("favorites/create returned error: %v", err)
+	}
+	if *favorite.Status.ID != *status.ID || *srv.User(int64(*user.ID)).FavouritesCount != 1 {
+		t.Errorf("favorites/create returned %+v", favorite)
+	}
+
+	err := post(t, client, "favorites/create.json", &idRequest{*status.ID}, nil)
+	if code := errorCode(err); code != ErrAlreadyFavorited {
+		t.Errorf("favorites/create twice returned %v, want error code %d", err, ErrAlreadyFavorited)
+	}
+
+	if err := post(t, client, "favorites/destroy.json", &idRequest{*status.ID}, nil); err != nil {
+		t.Errorf("favorites/destroy returned error: %v", err)
+	}
+	err = post(t, client, "favorites/destroy.json", &idRequest{*status.ID}, nil)
+	if code := errorCode(err); code != ErrNotFavorited {
+		t.Errorf("favorites/destroy twice returned %v, want error code %d", err, ErrNotFavorited)
+	}
+}
+
+func TestServer_repostWithoutText(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, authorToken := srv.AddUser("author")
+	_, readerToken := srv.AddUser("reader")
+	reader := srv.Client(readerToken)
+
+	first, _, _ := srv.Client(authorToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("first")})
+	second, _, _ := srv.Client(authorToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("second")})
+
+	for _, id := range []int64{*first.ID, *second.ID} {
+		if _, _, err := reader.Statuses.Repost(&weibo.RepostRequest{ID: id}); err != nil {
+			t.Errorf("Statuses.Repost of %v returned error: %v", id, err)
+		}
+	}
+}
+
+func TestServer_destroyStatus(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, authorToken := srv.AddUser("author")
+	reader, readerToken := srv.AddUser("reader")
+	client := srv.Client(readerToken)
+
+	original, _, _ := srv.Client(authorToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("original")})
+	repost, _, err := client.Statuses.Repost(&weibo.RepostRequest{ID: *original.ID})
+	if err != nil {
+		t.Fatalf("Statuses.Repost returned error: %v", err)
+	}
+	if err := post(t, client, "favorites/create.json", &idRequest{*repost.ID}, nil); err != nil {
+		t.Fatalf("favorites/create returned error: %v", err)
+	}
+
+	if err := post(t, client, "statuses/destroy.json", &idRequest{*repost.ID}, nil); err != nil {
+		t.Fatalf("statuses/destroy returned error: %v", err)
+	}
+
+	if got := srv.Status(*original.ID); *got.RepostsCount != 0 {
+		t.Errorf("original status reposts = %v, want 0", *got.RepostsCount)
+	}
+	if got := srv.User(int64(*reader.ID)); *got.FavouritesCount != 0 {
+		t.Errorf("reader favourites = %v, want 0", *got.FavouritesCount)
+	}
+	var favorites struct {
+		TotalNumber int `json:"total_number"`
+	}
+	req, _ := client.NewRequest("GET", "favorites.json", nil)
+	if _, err := client.Do(req, &favorites); err != nil {
+		t.Fatalf("favorites returned error: %v", err)
+	}
+	if favorites.TotalNumber != 0 {
+		t.Errorf("favorites returned %d favorites, want 0", favorites.TotalNumber)
+	}
+}
+
+func TestServer_unreadStatus(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	author, authorToken := srv.AddUser("author")
+	reader, readerToken := srv.AddUser("reader")
+	client := srv.Client(readerToken)
+
+	if err := post(t, client, "friendships/create.json", &uidRequest{*author.ID}, nil); err != nil {
+		t.Fatalf("friendships/create returned error: %v", err)
+	}
+	srv.Client(authorToken).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")})
+
+	count, _, err := client.Remind.UnreadCount(strconv.Itoa(*reader.ID))
+	if err != nil {
+		t.Fatalf("Remind.UnreadCount returned error: %v", err)
+	}
+	if *count.Status != 1 {
+		t.Errorf("Remind.UnreadCount returned %v unread statuses, want 1", *count.Status)
+	}
+}
+
+func TestServer_copies(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	user, token := srv.AddUser("larrylv")
+	status, _, _ := srv.Client(token).Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")})
+
+	*user.StatusesCount = 10
+	srv.Status(*status.ID).User.StatusesCount = weibo.Int(10)
+
+	if got := srv.User(int64(*user.ID)); *got.StatusesCount != 1 {
+		t.Errorf("user statuses = %v after modifying copies, want 1", *got.StatusesCount)
+	}
+}
+
+func TestServer_method(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, token := srv.AddUser("larrylv")
+	client := srv.Client(token)
+
+	req, _ := client.NewRequest("GET", "statuses/update.json?status=hello", nil)
+	_, err := client.Do(req, nil)
+	if code := errorCode(err); code != ErrMethodNotAllowed {
+		t.Errorf("GET statuses/update returned %v, want error code %d", err, ErrMethodNotAllowed)
+	}
+
+	timeline, _, _ := client.Statuses.UserTimeline(nil)
+	if len(timeline.Statuses) != 0 {
+		t.Errorf("GET statuses/update created statuses %+v", timeline.Statuses)
+	}
+}
+
+func TestServer_rateLimitStatus(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, token := srv.AddUser("larrylv")
+	srv.Now = func() time.Time { return time.Date(2026, 10, 19, 10, 59, 30, 0, time.UTC) }
+
+	status, _, err := srv.Client(token).Account.RateLimitStatus()
+	if err != nil {
+		t.Fatalf("Account.RateLimitStatus returned error: %v", err)
+	}
+	if *status.RemainingUserHits != *status.UserLimit || *status.ResetTimeInSeconds != 30 {
+		t.Errorf("Account.RateLimitStatus returned %+v", status)
+	}
+}
+
+func TestServer_Fail(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	_, token := srv.AddUser("larrylv")
+	client := srv.Client(token)
+
+	srv.Fail("statuses/update", http.StatusForbidden, 10023, "User requests out of rate limit!")
+	_, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")})
+	if code := errorCode(err); code != 10023 {
+		t.Errorf("Statuses.Create returned %v, want error code 10023", err)
+	}
+
+	srv.Recover("statuses/update")
+	if _, _, err := client.Statuses.Create(&weibo.StatusRequest{Status: weibo.String("hello")}); err != nil {
+		t.Errorf("Statuses.Create returned error: %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package weibotest
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+// tag is a tag of a user.  Tags with the same name share their ID across
+// users, as they do on Weibo.
+type tag struct {
+	ID     int64
+	Name   string
+	Weight int
+}
+
+// MarshalJSON encodes t keyed by its ID, as the Weibo API does, i.e.
+// {"1001": "golang", "weight": "20"}.
+func (t *tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{
+		strconv.FormatInt(t.ID, 10): t.Name,
+		"weight":                    strconv.Itoa(t.Weight),
+	})
+}
+
+// tagID represents the ID of a created or destroyed tag.
+type tagID struct {
+	TagID int64 `json:"tagid"`
+}
+
+// int64List returns the comma separated int64 parameter key of form.
+func int64List(form url.Values, key string) ([]int64, *apiError) {
+	v := form.Get(key)
+	if v == "" {
+		return nil, paramError("miss required parameter (%s)", key)
+	}
+
+	var ids []int64
+	for _, s := range strings.Split(v, ",") {
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, valueError(key)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// userTags returns the tags of u, heaviest first.
+func (s *Server) userTags(u *weibo.User) []*tag {
+	tags := append([]*tag{}, s.tags[userID(u)]...)
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].Weight > tags[j].Weight })
+	return tags
+}
+
+// hasTag reports whether user has the tag id.
+func (s *Server) hasTag(user *weibo.User, id int64) bool {
+	for _, t := range s.tags[userID(user)] {
+		if t.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// removeTag removes the tag id of user, reporting whether user had it.
+func (s *Server) removeTag(user *weibo.User, id int64) bool {
+	tags := s.tags[userID(user)]
+	for i, t := range tags {
+		if t.ID == id {
+			s.tags[userID(user)] = append(tags[:i:i], tags[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listTags(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	u, err := s.targetUser(form, user)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := s.userTags(u)
+	start, end := page(form, len(tags))
+	return tags[start:end], nil
+}
+
+func (s *Server) listTagsBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	uids, err := int64List(form, "uids")
+	if err != nil {
+		return nil, err
+	}
+
+	result := []interface{}{}
+	for _, uid := range uids {
+		if u, ok := s.users[uid]; ok {
+			result = append(result, map[string]interface{}{"id": uid, "tags": s.userTags(u)})
+		}
+	}
+	return result, nil
+}
+
+// tagSuggestions suggests the tags of other users which user does not have,
+// the most common first.
+func (s *Server) tagSuggestions(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	own := make(map[int64]bool)
+	for _, t := range s.tags[userID(user)] {
+		own[t.ID] = true
+	}
+
+	users := make(map[int64]int)
+	for _, tags := range s.tags {
+		for _, t := range tags {
+			if !own[t.ID] {
+				users[t.ID]++
+			}
+		}
+	}
+
+	ids := make([]int64, 0, len(users))
+	for id := range users {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if users[ids[i]] != users[ids[j]] {
+			return users[ids[i]] > users[ids[j]]
+		}
+		return ids[i] < ids[j]
+	})
+
+	names := make(map[int64]string, len(s.tagIDs))
+	for name, id := range s.tagIDs {
+		names[id] = name
+	}
+
+	suggestions := []map[string]string{}
+	start, end := page(form, len(ids))
+	for _, id := range ids[start:end] {
+		suggestions = append(suggestions, map[string]string{"id": strconv.FormatInt(id, 10), "value": names[id]})
+	}
+	return suggestions, nil
+}
+
+func (s *Server) createTags(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	if form.Get("tags") == "" {
+		return nil, paramError("miss required parameter (tags)")
+	}
+
+	created := []tagID{}
+	for _, name := range strings.Split(form.Get("tags"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		id, ok := s.tagIDs[name]
+		if !ok {
+			id = s.newID()
+			s.tagIDs[name] = id
+		}
+		if s.hasTag(user, id) {
+			continue
+		}
+		s.tags[userID(user)] = append(s.tags[userID(user)], &tag{ID: id, Name: name})
+		created = append(created, tagID{id})
+	}
+	return created, nil
+}
+
+func (s *Server) destroyTag(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	id, err := int64Param(form, "tag_id")
+	if err != nil {
+		return nil, err
+	}
+	if !s.removeTag(user, id) {
+		return nil, valueError("tag_id")
+	}
+	return map[string]bool{"result": true}, nil
+}
+
+func (s *Server) destroyTagBatch(user *weibo.User, form url.Values) (interface{}, *apiError) {
+	ids, err := int64List(form, "ids")
+	if err != nil {
+		return nil, err
+	}
+
+	destroyed := []tagID{}
+	for _, id := range ids {
+		if s.removeTag(user, id) {
+			destroyed = append(destroyed, tagID{id})
+		}
+	}
+	return destroyed, nil
+}
@@ -0,0 +1,70 @@
+package weibotest
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/larrylv/go-weibo/weibo"
+)
+
+func TestServer_tags(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	alice, aliceToken := srv.AddUser("alice")
+	bob, bobToken := srv.AddUser("bob")
+	client := srv.Client(aliceToken)
+
+	ids, _, err := client.Tags.Create(&weibo.TagRequest{Tags: []string{"golang", "weibo"}})
+	if err != nil {
+		t.Fatalf("Tags.Create returned error: %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("Tags.Create returned %v, want 2 IDs", ids)
+	}
+
+	tags, _, err := client.Tags.List(&weibo.TagListOptions{UID: strconv.Itoa(*alice.ID)})
+	if err != nil {
+		t.Fatalf("Tags.List returned error: %v", err)
+	}
+	if len(tags) != 2 || *tags[0].ID != ids[0] || *tags[0].Name != "golang" {
+		t.Errorf("Tags.List returned %+v", tags)
+	}
+
+	// tags are shared by name, and suggested to users without them
+	bobIDs, _, _ := srv.Client(bobToken).Tags.Create(&weibo.TagRequest{Tags: []string{"golang"}})
+	if len(bobIDs) != 1 || bobIDs[0] != ids[0] {
+		t.Errorf("Tags.Create returned %v, want [%v]", bobIDs, ids[0])
+	}
+	suggestions, _, err := srv.Client(bobToken).Tags.Suggestions(nil)
+	if err != nil {
+		t.Fatalf("Tags.Suggestions returned error: %v", err)
+	}
+	if len(suggestions) != 1 || *suggestions[0].Name != "weibo" {
+		t.Errorf("Tags.Suggestions returned %+v", suggestions)
+	}
+
+	batch, _, err := client.Tags.ListBatch([]string{strconv.Itoa(*alice.ID), strconv.Itoa(*bob.ID)})
+	if err != nil {
+		t.Fatalf("Tags.ListBatch returned error: %v", err)
+	}
+	if len(batch) != 2 || len(batch[0].Tags) != 2 || len(batch[1].Tags) != 1 {
+		t.Errorf("Tags.ListBatch returned %+v", batch)
+	}
+
+	if _, err := client.Tags.Destroy(ids[0]); err != nil {
+		t.Errorf("Tags.Destroy returned error: %v", err)
+	}
+	_, err = client.Tags.Destroy(ids[0])
+	if code := errorCode(err); code != ErrParameterValue {
+		t.Errorf("Tags.Destroy twice returned %v, want error code %d", err, ErrParameterValue)
+	}
+
+	destroyed, _, err := client.Tags.DestroyBatch([]int64{ids[0], ids[1]})
+	if err != nil {
+		t.Fatalf("Tags.DestroyBatch returned error: %v", err)
+	}
+	if len(destroyed) != 1 || destroyed[0] != ids[1] {
+		t.Errorf("Tags.DestroyBatch returned %v, want [%v]", destroyed, ids[1])
+	}
+}